@@ -0,0 +1,187 @@
+package ads
+
+// AVLTree is a self-balancing binary search tree where the heights of the two child subtrees of
+// any node differ by at most one. It stores unique elements sorted by a Comparator.
+type AVLTree struct {
+	root *bstNode
+	cmp  Comparator
+}
+
+// NewAVLTree returns an empty AVL tree ordered by the given comparator.
+func NewAVLTree(cmp Comparator) *AVLTree {
+	return &AVLTree{cmp: cmp}
+}
+
+func avlHeight(n *bstNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// avlUpdate recomputes the height and size of n from its children.
+func avlUpdate(n *bstNode) {
+	lh, rh := avlHeight(n.left), avlHeight(n.right)
+	if lh > rh {
+		n.rank = lh + 1
+	} else {
+		n.rank = rh + 1
+	}
+	n.size = bstSize(n.left) + bstSize(n.right) + 1
+}
+
+func avlRotateRight(n *bstNode) *bstNode {
+	l := n.left
+	n.left, l.right = l.right, n
+	avlUpdate(n)
+	avlUpdate(l)
+	return l
+}
+
+func avlRotateLeft(n *bstNode) *bstNode {
+	r := n.right
+	n.right, r.left = r.left, n
+	avlUpdate(n)
+	avlUpdate(r)
+	return r
+}
+
+// avlBalance restores the AVL property of n, assuming both children are balanced, and returns the
+// new root of the subtree.
+func avlBalance(n *bstNode) *bstNode {
+	avlUpdate(n)
+	switch bf := avlHeight(n.left) - avlHeight(n.right); {
+	case bf > 1:
+		if avlHeight(n.left.left) < avlHeight(n.left.right) {
+			n.left = avlRotateLeft(n.left)
+		}
+		return avlRotateRight(n)
+	case bf < -1:
+		if avlHeight(n.right.right) < avlHeight(n.right.left) {
+			n.right = avlRotateRight(n.right)
+		}
+		return avlRotateLeft(n)
+	}
+	return n
+}
+
+func (t *AVLTree) insert(n *bstNode, v interface{}) *bstNode {
+	if n == nil {
+		return &bstNode{value: v, rank: 1, size: 1}
+	}
+	switch c := t.cmp(v, n.value); {
+	case c < 0:
+		n.left = t.insert(n.left, v)
+	case c > 0:
+		n.right = t.insert(n.right, v)
+	default:
+		return n
+	}
+	return avlBalance(n)
+}
+
+// removeMin detaches the smallest node of the subtree rooted at n, returning the new subtree root
+// and the detached node.
+func (t *AVLTree) removeMin(n *bstNode) (*bstNode, *bstNode) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var min *bstNode
+	n.left, min = t.removeMin(n.left)
+	return avlBalance(n), min
+}
+
+func (t *AVLTree) remove(n *bstNode, v interface{}) *bstNode {
+	if n == nil {
+		return nil
+	}
+	switch c := t.cmp(v, n.value); {
+	case c < 0:
+		n.left = t.remove(n.left, v)
+	case c > 0:
+		n.right = t.remove(n.right, v)
+	default:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		right, min := t.removeMin(n.right)
+		min.left, min.right = n.left, right
+		// Avoid memory leaks (free references for garbage collector)
+		n.left, n.right = nil, nil
+		n = min
+	}
+	return avlBalance(n)
+}
+
+// Add inserts v into the tree. Elements already stored are ignored.
+func (t *AVLTree) Add(v interface{}) {
+	t.root = t.insert(t.root, v)
+}
+
+// Remove deletes v from the tree (if exists).
+func (t *AVLTree) Remove(v interface{}) {
+	t.root = t.remove(t.root, v)
+}
+
+// Contains returns whether an element is stored in the tree or not.
+func (t *AVLTree) Contains(v interface{}) bool {
+	return bstFind(t.root, t.cmp, v) != nil
+}
+
+// Size returns the number of elements stored in the tree.
+func (t *AVLTree) Size() int {
+	return bstSize(t.root)
+}
+
+// Empty removes all elements from the tree.
+func (t *AVLTree) Empty() {
+	t.root = nil
+}
+
+// Min returns the smallest element of the tree.
+func (t *AVLTree) Min() (interface{}, error) {
+	return bstMin(t.root)
+}
+
+// Max returns the largest element of the tree.
+func (t *AVLTree) Max() (interface{}, error) {
+	return bstMax(t.root)
+}
+
+// Floor returns the largest element smaller than or equal to v.
+func (t *AVLTree) Floor(v interface{}) (interface{}, error) {
+	return bstValue(bstUpperBound(t.root, t.cmp, v, true), "floor", v)
+}
+
+// Ceiling returns the smallest element greater than or equal to v.
+func (t *AVLTree) Ceiling(v interface{}) (interface{}, error) {
+	return bstValue(bstLowerBound(t.root, t.cmp, v, true), "ceiling", v)
+}
+
+// Predecessor returns the largest element strictly smaller than v.
+func (t *AVLTree) Predecessor(v interface{}) (interface{}, error) {
+	return bstValue(bstUpperBound(t.root, t.cmp, v, false), "predecessor", v)
+}
+
+// Successor returns the smallest element strictly greater than v.
+func (t *AVLTree) Successor(v interface{}) (interface{}, error) {
+	return bstValue(bstLowerBound(t.root, t.cmp, v, false), "successor", v)
+}
+
+// String returns a string representation of the tree content in sorted order.
+func (t *AVLTree) String() string {
+	return bstString(t.root)
+}
+
+// Iterator returns an iterable over the elements of the tree in sorted order.
+func (t *AVLTree) Iterator() Iterable {
+	return newBSTIterable(t.root, t.cmp, nil, nil)
+}
+
+// Range returns an iterable over the elements in the closed range [lo, hi] in sorted order.
+func (t *AVLTree) Range(lo, hi interface{}) Iterable {
+	return newBSTIterable(t.root, t.cmp, lo, hi)
+}
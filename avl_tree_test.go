@@ -0,0 +1,75 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// checkAVLInvariants verifies the search order, the sizes and the heights of the tree and that
+// every node is balanced.
+func checkAVLInvariants(t *testing.T, tree *AVLTree) {
+	t.Helper()
+	checkBSTInvariants(t, tree.root, tree.cmp)
+	var height func(n *bstNode) int
+	height = func(n *bstNode) int {
+		if n == nil {
+			return 0
+		}
+		lh, rh := height(n.left), height(n.right)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Fatalf("node %v is unbalanced, heights %d and %d", n.value, lh, rh)
+		}
+		h := lh + 1
+		if rh > lh {
+			h = rh + 1
+		}
+		if n.rank != h {
+			t.Fatalf("node %v has height %d, want %d", n.value, n.rank, h)
+		}
+		return h
+	}
+	height(tree.root)
+}
+
+func TestAVLTree_ThroughOps(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		t.Run(fmt.Sprintf("n = %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			tree := NewAVLTree(IntComparator)
+			control := map[int]bool{}
+			for i := 0; i < 4*n; i++ {
+				v := r.Intn(2 * n)
+				if r.Intn(3) == 0 {
+					tree.Remove(v)
+					delete(control, v)
+				} else {
+					tree.Add(v)
+					control[v] = true
+				}
+				checkAVLInvariants(t, tree)
+				if got := tree.Contains(v); got != control[v] {
+					t.Fatalf("Contains(%d): %v, want %v", v, got, control[v])
+				}
+				if tree.Size() != len(control) {
+					t.Fatalf("Size(): %d, want %d", tree.Size(), len(control))
+				}
+			}
+		})
+	}
+}
+
+func TestAVLTree_SortedInsertions(t *testing.T) {
+	tree := NewAVLTree(IntComparator)
+	for i := 0; i < 1023; i++ {
+		tree.Add(i)
+	}
+	checkAVLInvariants(t, tree)
+	// A perfectly balanced tree of 1023 nodes has height 10, AVL trees stay within 1.44·log n.
+	if h := avlHeight(tree.root); h > 14 {
+		t.Errorf("tree height is %d after sorted insertions", h)
+	}
+	if got := tree.String(); got[:13] != "[0, 1, 2, 3, " {
+		t.Errorf("String(): %s", got)
+	}
+}
@@ -0,0 +1,194 @@
+package ads
+
+import "fmt"
+
+// binomialHeapNode is a node of a binomial tree. Siblings are sorted by increasing degree.
+type binomialHeapNode struct {
+	item                   *HeapItem
+	parent, child, sibling *binomialHeapNode
+	degree                 int
+	owner                  *heapOwner
+}
+
+// BinomialHeap is a Heap represented as a forest of binomial trees with O(log n) merging.
+type BinomialHeap struct {
+	// head is the first root of the forest, roots are sorted by increasing degree.
+	head   *binomialHeapNode
+	length int
+	owner  *heapOwner
+}
+
+// NewBinomialHeap returns an empty binomial heap.
+func NewBinomialHeap() *BinomialHeap {
+	return &BinomialHeap{owner: &heapOwner{}}
+}
+
+// link makes y the leftmost child of x. Both trees must have the same degree.
+func (h *BinomialHeap) link(y, x *binomialHeapNode) {
+	y.parent = x
+	y.sibling = x.child
+	x.child = y
+	x.degree++
+}
+
+// mergeRoots returns the combined root list of a and b sorted by increasing degree.
+func (h *BinomialHeap) mergeRoots(a, b *binomialHeapNode) *binomialHeapNode {
+	var head binomialHeapNode
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// union combines the given root list into the heap, linking trees of equal degree.
+func (h *BinomialHeap) union(roots *binomialHeapNode) {
+	h.head = h.mergeRoots(h.head, roots)
+	if h.head == nil {
+		return
+	}
+	var prev *binomialHeapNode
+	x, next := h.head, h.head.sibling
+	for next != nil {
+		switch {
+		case x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree):
+			prev, x = x, next
+		case x.item.Key <= next.item.Key:
+			x.sibling = next.sibling
+			h.link(next, x)
+		default:
+			if prev == nil {
+				h.head = next
+			} else {
+				prev.sibling = next
+			}
+			h.link(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+}
+
+// minRoot returns the root with the smallest key and the root that precedes it.
+func (h *BinomialHeap) minRoot() (min, prev *binomialHeapNode) {
+	var p *binomialHeapNode
+	for n := h.head; n != nil; p, n = n, n.sibling {
+		if min == nil || n.item.Key < min.item.Key {
+			min, prev = n, p
+		}
+	}
+	return min, prev
+}
+
+// Insert a new value with the given key, returns a handle to the stored item.
+func (h *BinomialHeap) Insert(key int, v interface{}) *HeapItem {
+	item := &HeapItem{Key: key, Value: v}
+	n := &binomialHeapNode{item: item, owner: h.owner}
+	item.node = n
+	h.union(n)
+	h.length++
+	return item
+}
+
+// FindMin returns the item with the smallest key without removing it.
+func (h *BinomialHeap) FindMin() (*HeapItem, error) {
+	if h.head == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	min, _ := h.minRoot()
+	return min.item, nil
+}
+
+// DeleteMin removes and returns the item with the smallest key.
+func (h *BinomialHeap) DeleteMin() (*HeapItem, error) {
+	if h.head == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	min, prev := h.minRoot()
+	if prev == nil {
+		h.head = min.sibling
+	} else {
+		prev.sibling = min.sibling
+	}
+	// Children are sorted by decreasing degree, reverse them before the union.
+	var children *binomialHeapNode
+	for c := min.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+	h.union(children)
+	h.length--
+	min.item.node = nil
+	return min.item, nil
+}
+
+// DecreaseKey lowers the key of an item stored in the heap. Items are swapped between nodes
+// while bubbling up so handles remain valid.
+func (h *BinomialHeap) DecreaseKey(item *HeapItem, key int) error {
+	if err := validateDecreaseKey(item, key); err != nil {
+		return err
+	}
+	n, ok := item.node.(*binomialHeapNode)
+	if !ok || n.item != item || n.owner.find() != h.owner {
+		return fmt.Errorf("item %v is not stored in the heap", item)
+	}
+	item.Key = key
+	for p := n.parent; p != nil && p.item.Key > n.item.Key; n, p = p, p.parent {
+		n.item, p.item = p.item, n.item
+		n.item.node = n
+		p.item.node = p
+	}
+	return nil
+}
+
+// Merge moves all the items of the given heap into this one, leaving the other heap empty.
+func (h *BinomialHeap) Merge(other Heap) error {
+	o, ok := other.(*BinomialHeap)
+	if !ok {
+		return fmt.Errorf("cannot merge %T into %T", other, h)
+	}
+	if o == h {
+		return nil
+	}
+	h.union(o.head)
+	h.length += o.length
+	o.owner.parent = h.owner
+	o.head, o.length, o.owner = nil, 0, &heapOwner{}
+	return nil
+}
+
+// Size returns the number of elements stored in the heap.
+func (h *BinomialHeap) Size() int {
+	return h.length
+}
+
+// Empty removes all elements from the heap.
+func (h *BinomialHeap) Empty() {
+	// Detach every item from its node so stale handles are rejected by DecreaseKey.
+	stack := []*binomialHeapNode{}
+	for n := h.head; n != nil; n = n.sibling {
+		stack = append(stack, n)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for c := n.child; c != nil; c = c.sibling {
+			stack = append(stack, c)
+		}
+		n.item.node = nil
+	}
+	h.head, h.length = nil, 0
+}
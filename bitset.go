@@ -0,0 +1,378 @@
+package ads
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// BitSet is a set of non-negative integers stored as a vector of bits. It grows as needed to hold
+// the largest bit set or flipped, just like Array.
+type BitSet struct {
+	words []uint64
+	// length is the number of addressable bits; bits past it are always clear.
+	length int
+}
+
+// NewBitSet returns a bit set of n clear bits.
+func NewBitSet(n uint) *BitSet {
+	return &BitSet{words: make([]uint64, (n+63)/64), length: int(n)}
+}
+
+func bitSetIndexError(i int) error {
+	return fmt.Errorf("invalid bit index %d", i)
+}
+
+// grow makes the set long enough to hold bit i.
+func (b *BitSet) grow(i int) {
+	if i < b.length {
+		return
+	}
+	b.length = i + 1
+	if need := (b.length + 63) / 64; need > len(b.words) {
+		b.words = append(b.words, make([]uint64, need-len(b.words))...)
+	}
+}
+
+// Set turns bit i on, growing the set if needed.
+func (b *BitSet) Set(i int) error {
+	if i < 0 {
+		return bitSetIndexError(i)
+	}
+	b.grow(i)
+	b.words[i/64] |= 1 << uint(i%64)
+	return nil
+}
+
+// Clear turns bit i off.
+func (b *BitSet) Clear(i int) error {
+	if i < 0 {
+		return bitSetIndexError(i)
+	}
+	if i < b.length {
+		b.words[i/64] &^= 1 << uint(i%64)
+	}
+	return nil
+}
+
+// Flip toggles bit i, growing the set if needed.
+func (b *BitSet) Flip(i int) error {
+	if i < 0 {
+		return bitSetIndexError(i)
+	}
+	b.grow(i)
+	b.words[i/64] ^= 1 << uint(i%64)
+	return nil
+}
+
+// Test returns whether bit i is set.
+func (b *BitSet) Test(i int) bool {
+	return i >= 0 && i < b.length && b.words[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Len returns the number of addressable bits: the size given to NewBitSet or one past the largest
+// bit set or flipped since.
+func (b *BitSet) Len() int {
+	return b.length
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// combine returns a new set as long as the longest of b and other, with op applied to each pair
+// of words. op must map two zero words to zero.
+func (b *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+	r := NewBitSet(uint(maxInts(b.length, other.length)))
+	for i := range r.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+		r.words[i] = op(x, y)
+	}
+	return r
+}
+
+// Union returns a new set with the bits set in b or other.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersection returns a new set with the bits set in both b and other.
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns a new set with the bits set in b but not in other.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// SymmetricDifference returns a new set with the bits set in exactly one of b and other.
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// Equal returns whether b and other have the same bits set, regardless of their length.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return b.SymmetricDifference(other).Count() == 0
+}
+
+// NextSet returns the first set bit at or after i. Returns -1, false if there's none.
+func (b *BitSet) NextSet(i int) (int, bool) {
+	return b.next(i, 0)
+}
+
+// NextClear returns the first clear bit at or after i and before Len. Returns -1, false if there's
+// none.
+func (b *BitSet) NextClear(i int) (int, bool) {
+	return b.next(i, ^uint64(0))
+}
+
+// next returns the first bit at or after i that is set in the bits XOR mask, skipping whole
+// words.
+func (b *BitSet) next(i int, mask uint64) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	for w := i / 64; w < len(b.words); w++ {
+		x := b.words[w] ^ mask
+		if w == i/64 {
+			x &^= 1<<uint(i%64) - 1
+		}
+		if x != 0 {
+			if j := w*64 + bits.TrailingZeros64(x); j < b.length {
+				return j, true
+			}
+			return -1, false
+		}
+	}
+	return -1, false
+}
+
+// Iterator returns an iterable over the set bits in increasing order.
+func (b *BitSet) Iterator() Iterable {
+	return &BitSetIterable{b: b, next: (*BitSet).NextSet}
+}
+
+// ClearIterator returns an iterable over the clear bits before Len in increasing order.
+func (b *BitSet) ClearIterator() Iterable {
+	return &BitSetIterable{b: b, next: (*BitSet).NextClear}
+}
+
+// String returns the set bits as a set, e.g. {1, 4, 9}.
+func (b *BitSet) String() string {
+	var s strings.Builder
+	s.WriteString("{")
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		if s.Len() > 1 {
+			s.WriteString(", ")
+		}
+		s.WriteString(fmt.Sprintf("%d", i))
+	}
+	s.WriteString("}")
+	return s.String()
+}
+
+// BitSetIterable implements Iterable interface for BitSet, enumerating bit positions with NextSet
+// or NextClear.
+type BitSetIterable struct {
+	b    *BitSet
+	next func(b *BitSet, i int) (int, bool)
+	// i is the position to search from.
+	i int
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (it *BitSetIterable) Scan() bool {
+	_, ok := it.next(it.b, it.i)
+	return ok
+}
+
+// Next returns the next element in the iterable.
+func (it *BitSetIterable) Next() (interface{}, error) {
+	j, ok := it.next(it.b, it.i)
+	if !ok {
+		return nil, fmt.Errorf("no more bits")
+	}
+	it.i = j + 1
+	return j, nil
+}
+
+const (
+	// rankSelectBlockWords is the number of words in each superblock of a RankSelect.
+	rankSelectBlockWords = 8
+	rankSelectBlockBits  = 64 * rankSelectBlockWords
+	// rankSelectSampleRate is the number of set (or clear) bits between select samples.
+	rankSelectSampleRate = 512
+	// rankSelectSparseSpan is the number of superblocks from which the positions of the bits
+	// between two samples are stored, since they take no more space than the superblocks.
+	rankSelectSparseSpan = 64
+)
+
+// RankSelect is a static index over a bit vector answering rank (how many bits are set before a
+// position) and select (where is the k-th set bit) queries. It keeps a copy of the n bits plus a
+// 64-bit count per superblock of 512 bits, a 16-bit count per word within its superblock and a
+// sample of the superblock holding every 512-th set and clear bit, which adds n/2 bits. When the
+// bits between two samples span many superblocks, their positions are stored instead, which adds
+// at most about n more bits. The overhead is thus a constant fraction of n rather than o(n), in
+// exchange for simple constant time queries.
+type RankSelect struct {
+	words []uint64
+	n     int
+	ones  int
+	// super[j] is the number of set bits before superblock j.
+	super []int
+	// block[w] is the number of set bits before word w within its superblock.
+	block []uint16
+	// samples[1][t] is the superblock holding the (512t+1)-th set bit, samples[0] for clear bits.
+	samples [2][]int
+	// sparse[1][t] holds the positions of the set bits from sample t on if they span at least
+	// rankSelectSparseSpan superblocks, nil otherwise. sparse[0] for clear bits.
+	sparse [2][][]int
+}
+
+// NewRankSelect returns an index over the current bits of b. Later changes to b aren't reflected.
+func NewRankSelect(b *BitSet) *RankSelect {
+	// Words are padded to whole superblocks with at least one word past the last bit, so rank
+	// queries at the end of the vector don't need special cases.
+	nsuper := b.length/rankSelectBlockBits + 1
+	r := &RankSelect{
+		words: make([]uint64, nsuper*rankSelectBlockWords),
+		n:     b.length,
+		super: make([]int, nsuper+1),
+		block: make([]uint16, nsuper*rankSelectBlockWords),
+	}
+	copy(r.words, b.words)
+	for w, x := range r.words {
+		if w%rankSelectBlockWords == 0 {
+			r.super[w/rankSelectBlockWords] = r.ones
+		}
+		r.block[w] = uint16(r.ones - r.super[w/rankSelectBlockWords])
+		r.ones += bits.OnesCount64(x)
+	}
+	r.super[nsuper] = r.ones
+	for bit := range r.samples {
+		for j := 0; j < nsuper; j++ {
+			for len(r.samples[bit])*rankSelectSampleRate < r.before(j+1, bit) {
+				r.samples[bit] = append(r.samples[bit], j)
+			}
+		}
+		r.sparse[bit] = make([][]int, len(r.samples[bit]))
+		for t := range r.samples[bit] {
+			lo, hi := r.sampleSpan(t, bit)
+			if hi-lo < rankSelectSparseSpan {
+				continue
+			}
+			// k is the rank of the bit at i among the bits equal to bit.
+			k := r.before(lo, bit)
+			positions := make([]int, 0, rankSelectSampleRate)
+			for i := lo * rankSelectBlockBits; i < r.n && len(positions) < cap(positions); i++ {
+				if int(r.words[i/64]>>uint(i%64)&1) != bit {
+					continue
+				}
+				if k++; k > t*rankSelectSampleRate {
+					positions = append(positions, i)
+				}
+			}
+			r.sparse[bit][t] = positions
+		}
+	}
+	return r
+}
+
+// sampleSpan returns the first and last superblocks that may hold the bits equal to bit from
+// sample t to the next one.
+func (r *RankSelect) sampleSpan(t, bit int) (int, int) {
+	samples := r.samples[bit]
+	if t+1 < len(samples) {
+		return samples[t], samples[t+1]
+	}
+	return samples[t], len(r.super) - 2
+}
+
+// before returns the number of bits equal to bit before superblock j, padding included.
+func (r *RankSelect) before(j, bit int) int {
+	if bit == 1 {
+		return r.super[j]
+	}
+	return j*rankSelectBlockBits - r.super[j]
+}
+
+// Len returns the number of bits of the vector.
+func (r *RankSelect) Len() int {
+	return r.n
+}
+
+// Rank1 returns the number of set bits before position i, in constant time. i must be in
+// [0, Len].
+func (r *RankSelect) Rank1(i int) (int, error) {
+	if i < 0 || i > r.n {
+		return 0, fmt.Errorf("vector length is %d, got invalid position %d", r.n, i)
+	}
+	w := i / 64
+	return r.super[w/rankSelectBlockWords] + int(r.block[w]) +
+		bits.OnesCount64(r.words[w]&(1<<uint(i%64)-1)), nil
+}
+
+// Rank0 returns the number of clear bits before position i, in constant time. i must be in
+// [0, Len].
+func (r *RankSelect) Rank0(i int) (int, error) {
+	ones, err := r.Rank1(i)
+	return i - ones, err
+}
+
+// Select1 returns the position of the k-th set bit, counting from 1, in constant time. Unless
+// its position is stored, the superblock is found by binary search among the fewer than 64
+// between two samples, and then the bit is found within at most 8 words.
+func (r *RankSelect) Select1(k int) (int, error) {
+	if k < 1 || k > r.ones {
+		return 0, fmt.Errorf("vector has %d set bits, got invalid rank %d", r.ones, k)
+	}
+	return r.selectBit(k, 1), nil
+}
+
+// Select0 returns the position of the k-th clear bit, counting from 1. It works like Select1.
+func (r *RankSelect) Select0(k int) (int, error) {
+	if zeros := r.n - r.ones; k < 1 || k > zeros {
+		return 0, fmt.Errorf("vector has %d clear bits, got invalid rank %d", zeros, k)
+	}
+	return r.selectBit(k, 0), nil
+}
+
+// selectBit returns the position of the k-th bit equal to bit, which must exist.
+func (r *RankSelect) selectBit(k, bit int) int {
+	t := (k - 1) / rankSelectSampleRate
+	if positions := r.sparse[bit][t]; positions != nil {
+		return positions[(k-1)%rankSelectSampleRate]
+	}
+	lo, hi := r.sampleSpan(t, bit)
+	// Find the last superblock in [lo, hi] with fewer than k bits before it.
+	j := lo + sort.Search(hi-lo+1, func(j int) bool { return r.before(lo+j, bit) >= k }) - 1
+	k -= r.before(j, bit)
+	for w := j * rankSelectBlockWords; ; w++ {
+		x := r.words[w]
+		if bit == 0 {
+			x = ^x
+		}
+		if c := bits.OnesCount64(x); k > c {
+			k -= c
+			continue
+		}
+		// Drop the k-1 lowest set bits of the word.
+		for ; k > 1; k-- {
+			x &= x - 1
+		}
+		return w*64 + bits.TrailingZeros64(x)
+	}
+}
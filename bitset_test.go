@@ -0,0 +1,178 @@
+package ads
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// randomBitSet returns a bit set of n bits, each set with probability density, and the set bits.
+func randomBitSet(r *rand.Rand, n int, density float64) (*BitSet, []bool) {
+	b, want := NewBitSet(uint(n)), make([]bool, n)
+	for i := range want {
+		if r.Float64() < density {
+			b.Set(i)
+			want[i] = true
+		}
+	}
+	return b, want
+}
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(10)
+	for _, i := range []int{1, 4, 9, 64, 200} {
+		if err := b.Set(i); err != nil {
+			t.Fatalf("Set(%d) returned unexpected error; %v", i, err)
+		}
+	}
+	b.Clear(4)
+	b.Clear(1000) // Clearing past the end doesn't grow the set.
+	b.Flip(9)
+	b.Flip(130)
+	if got := b.String(); got != "{1, 64, 130, 200}" {
+		t.Errorf("String(): %s, want {1, 64, 130, 200}", got)
+	}
+	if b.Len() != 201 || b.Count() != 4 {
+		t.Errorf("Len() %d and Count() %d, want 201 and 4", b.Len(), b.Count())
+	}
+	if !b.Test(64) || b.Test(4) || b.Test(-1) || b.Test(1000) {
+		t.Error("Test() reports wrong bits")
+	}
+	for _, err := range []error{b.Set(-1), b.Clear(-1), b.Flip(-1)} {
+		if err == nil {
+			t.Error("negative index returned nil error, want error")
+		}
+	}
+	if diff := cmp.Diff([]interface{}{1, 64, 130, 200}, iterableToSlice(t, b.Iterator())); diff != "" {
+		t.Errorf("Iterator() mismatch (-want +got):\n%s", diff)
+	}
+	small := NewBitSet(0)
+	small.Set(1)
+	small.Set(3)
+	if diff := cmp.Diff([]interface{}{0, 2}, iterableToSlice(t, small.ClearIterator())); diff != "" {
+		t.Errorf("ClearIterator() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := small.Iterator().Next(); err != nil {
+		t.Errorf("Next() returned unexpected error; %v", err)
+	}
+	it := small.Iterator()
+	it.Next()
+	it.Next()
+	if _, err := it.Next(); err == nil {
+		t.Error("Next() past the last bit returned nil error, want error")
+	}
+}
+
+func TestBitSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		n, m := r.Intn(300), r.Intn(300)
+		a, wantA := randomBitSet(r, n, r.Float64())
+		b, wantB := randomBitSet(r, m, r.Float64())
+		ops := []struct {
+			name string
+			got  *BitSet
+			op   func(x, y bool) bool
+		}{
+			{name: "union", got: a.Union(b), op: func(x, y bool) bool { return x || y }},
+			{name: "intersection", got: a.Intersection(b), op: func(x, y bool) bool { return x && y }},
+			{name: "difference", got: a.Difference(b), op: func(x, y bool) bool { return x && !y }},
+			{
+				name: "symmetric difference",
+				got:  a.SymmetricDifference(b),
+				op:   func(x, y bool) bool { return x != y },
+			},
+		}
+		for _, op := range ops {
+			if op.got.Len() != maxInts(n, m) {
+				t.Fatalf("%s has length %d, want %d", op.name, op.got.Len(), maxInts(n, m))
+			}
+			count := 0
+			for j := 0; j < maxInts(n, m); j++ {
+				want := op.op(j < n && wantA[j], j < m && wantB[j])
+				if op.got.Test(j) != want {
+					t.Fatalf("%s: bit %d is %v, want %v", op.name, j, !want, want)
+				}
+				if want {
+					count++
+				}
+			}
+			if op.got.Count() != count {
+				t.Fatalf("%s: Count() %d, want %d", op.name, op.got.Count(), count)
+			}
+		}
+		if !a.Equal(a.Union(a.Intersection(b))) || a.Equal(b) != (a.SymmetricDifference(b).Count() == 0) {
+			t.Fatal("Equal() disagrees with the set operations")
+		}
+		for j := -1; j <= n; j++ {
+			wantSet, wantClear := -1, -1
+			for k := maxInts(j, 0); k < n; k++ {
+				if wantA[k] && wantSet == -1 {
+					wantSet = k
+				}
+				if !wantA[k] && wantClear == -1 {
+					wantClear = k
+				}
+			}
+			if got, ok := a.NextSet(j); got != wantSet || ok != (wantSet != -1) {
+				t.Fatalf("NextSet(%d): %d, %v, want %d", j, got, ok, wantSet)
+			}
+			if got, ok := a.NextClear(j); got != wantClear || ok != (wantClear != -1) {
+				t.Fatalf("NextClear(%d): %d, %v, want %d", j, got, ok, wantClear)
+			}
+		}
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// The longest vector has several samples spanning enough superblocks to store positions.
+	for _, n := range []int{0, 1, 63, 64, 65, 511, 512, 513, 5000, 70000, 300000} {
+		for _, density := range []float64{0, 0.001, 0.1, 0.5, 0.99, 1} {
+			b, want := randomBitSet(r, n, density)
+			rs := NewRankSelect(b)
+			if rs.Len() != n {
+				t.Fatalf("Len(): %d, want %d", rs.Len(), n)
+			}
+			ones, zeros := 0, 0
+			for i := 0; i <= n; i++ {
+				if got, err := rs.Rank1(i); err != nil || got != ones {
+					t.Fatalf("n=%d, density=%v: Rank1(%d): %d, %v, want %d", n, density, i, got, err, ones)
+				}
+				if got, _ := rs.Rank0(i); got != zeros {
+					t.Fatalf("n=%d, density=%v: Rank0(%d): %d, want %d", n, density, i, got, zeros)
+				}
+				if i == n {
+					break
+				}
+				if want[i] {
+					ones++
+					if got, err := rs.Select1(ones); err != nil || got != i {
+						t.Fatalf("n=%d, density=%v: Select1(%d): %d, %v, want %d", n, density, ones, got, err, i)
+					}
+				} else {
+					zeros++
+					if got, err := rs.Select0(zeros); err != nil || got != i {
+						t.Fatalf("n=%d, density=%v: Select0(%d): %d, %v, want %d", n, density, zeros, got, err, i)
+					}
+				}
+			}
+			for _, k := range []int{0, ones + 1} {
+				if _, err := rs.Select1(k); err == nil {
+					t.Errorf("Select1(%d) returned nil error with %d set bits, want error", k, ones)
+				}
+			}
+			for _, k := range []int{0, zeros + 1} {
+				if _, err := rs.Select0(k); err == nil {
+					t.Errorf("Select0(%d) returned nil error with %d clear bits, want error", k, zeros)
+				}
+			}
+			for _, i := range []int{-1, n + 1} {
+				if _, err := rs.Rank1(i); err == nil {
+					t.Errorf("Rank1(%d) returned nil error for %d bits, want error", i, n)
+				}
+			}
+		}
+	}
+}
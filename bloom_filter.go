@@ -0,0 +1,202 @@
+package ads
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// bloomFilterParameters returns the number of bits m and of hash functions k minimizing the space
+// of a Bloom filter holding n keys with false positive rate p: m = -n ln p / ln² 2 and
+// k = m/n ln 2.
+func bloomFilterParameters(n int, p float64) (int, int, error) {
+	if n < 1 {
+		return 0, 0, fmt.Errorf("expected number of keys must be positive, got %d", n)
+	}
+	if p <= 0 || p >= 1 {
+		return 0, 0, fmt.Errorf("false positive rate must be in (0, 1), got %v", p)
+	}
+	m := int(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return m, k, nil
+}
+
+// bloomFilterLocations calls f with the k positions among m of a key. They're derived from two
+// halves of a 64-bit FNV-1a hash with double hashing, h1 + i*h2, which performs like k independent
+// hash functions.
+func bloomFilterLocations(key string, m, k int, f func(i int)) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	// h2 is odd, so it never collapses all positions into one when m is a power of two.
+	h1, h2 := sum&math.MaxUint32, sum>>32|1
+	for i := 0; i < k; i++ {
+		f(int((h1 + uint64(i)*h2) % uint64(m)))
+	}
+}
+
+// BloomFilter is a probabilistic set of strings. Membership queries may return false positives,
+// at a rate bounded when sized, but never false negatives. Keys can't be removed.
+type BloomFilter struct {
+	bits []uint64
+	// m is the number of bits and k the number of hash functions.
+	m, k int
+	// n is the number of keys added.
+	n int
+}
+
+// NewBloomFilter returns an empty Bloom filter sized to hold n keys with a false positive rate of
+// at most p.
+func NewBloomFilter(n int, p float64) (*BloomFilter, error) {
+	m, k, err := bloomFilterParameters(n, p)
+	if err != nil {
+		return nil, err
+	}
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}, nil
+}
+
+// Add inserts key into the filter.
+func (f *BloomFilter) Add(key string) {
+	bloomFilterLocations(key, f.m, f.k, func(i int) {
+		f.bits[i/64] |= 1 << uint(i%64)
+	})
+	f.n++
+}
+
+// MayContain returns false if key was never added, and true if it probably was.
+func (f *BloomFilter) MayContain(key string) bool {
+	found := true
+	bloomFilterLocations(key, f.m, f.k, func(i int) {
+		found = found && f.bits[i/64]&(1<<uint(i%64)) != 0
+	})
+	return found
+}
+
+// Union adds every key of another filter to this one. Both filters must have the same size and
+// number of hash functions, e.g. by being created with the same parameters.
+func (f *BloomFilter) Union(other *BloomFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return fmt.Errorf("filters differ: %d bits and %d hashes, other has %d bits and %d hashes",
+			f.m, f.k, other.m, other.k)
+	}
+	for i, w := range other.bits {
+		f.bits[i] |= w
+	}
+	f.n += other.n
+	return nil
+}
+
+// Size returns the number of keys added to the filter, counting duplicates and the keys merged by
+// Union.
+func (f *BloomFilter) Size() int {
+	return f.n
+}
+
+// FalsePositiveRate returns the probability that MayContain returns true for a key never added,
+// estimated from the fraction of bits set.
+func (f *BloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// MarshalBinary encodes the filter as the number of bits, hash functions and keys followed by the
+// bits, all as big-endian 64-bit words.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8*(3+len(f.bits)))
+	binary.BigEndian.PutUint64(data, uint64(f.m))
+	binary.BigEndian.PutUint64(data[8:], uint64(f.k))
+	binary.BigEndian.PutUint64(data[16:], uint64(f.n))
+	for i, w := range f.bits {
+		binary.BigEndian.PutUint64(data[8*(3+i):], w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 24 {
+		return fmt.Errorf("encoded filter has %d bytes, want at least 24", len(data))
+	}
+	m := int(binary.BigEndian.Uint64(data))
+	k := int(binary.BigEndian.Uint64(data[8:]))
+	n := int(binary.BigEndian.Uint64(data[16:]))
+	words := (m + 63) / 64
+	if m < 1 || k < 1 || n < 0 || len(data) != 8*(3+words) {
+		return fmt.Errorf("invalid encoded filter of %d bytes with %d bits and %d hashes",
+			len(data), m, k)
+	}
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[8*(3+i):])
+	}
+	f.bits, f.m, f.k, f.n = bits, m, k, n
+	return nil
+}
+
+// CountingBloomFilter is a Bloom filter that keeps a counter instead of a bit per position, so keys
+// can be removed. Counters saturate at 255 and then are never decremented, which keeps false
+// negatives impossible.
+type CountingBloomFilter struct {
+	counters []uint8
+	k        int
+	n        int
+}
+
+// NewCountingBloomFilter returns an empty counting Bloom filter sized to hold n keys with a false
+// positive rate of at most p.
+func NewCountingBloomFilter(n int, p float64) (*CountingBloomFilter, error) {
+	m, k, err := bloomFilterParameters(n, p)
+	if err != nil {
+		return nil, err
+	}
+	return &CountingBloomFilter{counters: make([]uint8, m), k: k}, nil
+}
+
+// Add inserts key into the filter.
+func (f *CountingBloomFilter) Add(key string) {
+	bloomFilterLocations(key, len(f.counters), f.k, func(i int) {
+		if f.counters[i] < math.MaxUint8 {
+			f.counters[i]++
+		}
+	})
+	f.n++
+}
+
+// MayContain returns false if key isn't in the filter, and true if it probably is.
+func (f *CountingBloomFilter) MayContain(key string) bool {
+	found := true
+	bloomFilterLocations(key, len(f.counters), f.k, func(i int) {
+		found = found && f.counters[i] > 0
+	})
+	return found
+}
+
+// Remove deletes one occurrence of key from the filter. Returns an error, leaving the filter as it
+// was, if key is surely not in the filter. Removing a key that was never added but is a false
+// positive corrupts the filter.
+func (f *CountingBloomFilter) Remove(key string) error {
+	if !f.MayContain(key) {
+		return fmt.Errorf("key %q not found", key)
+	}
+	bloomFilterLocations(key, len(f.counters), f.k, func(i int) {
+		// Saturated counters lost track of how many keys they count.
+		if f.counters[i] > 0 && f.counters[i] < math.MaxUint8 {
+			f.counters[i]--
+		}
+	})
+	f.n--
+	return nil
+}
+
+// Size returns the number of keys in the filter.
+func (f *CountingBloomFilter) Size() int {
+	return f.n
+}
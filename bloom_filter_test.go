@@ -0,0 +1,158 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+)
+
+// measureFalsePositiveRate returns the fraction of keys never added that mayContain accepts.
+func measureFalsePositiveRate(mayContain func(string) bool, trials int) float64 {
+	positives := 0
+	for i := 0; i < trials; i++ {
+		if mayContain(fmt.Sprintf("absent-%d", i)) {
+			positives++
+		}
+	}
+	return float64(positives) / float64(trials)
+}
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		const n = 20000
+		f, err := NewBloomFilter(n, p)
+		if err != nil {
+			t.Fatalf("NewBloomFilter(%d, %v) returned unexpected error; %v", n, p, err)
+		}
+		for i := 0; i < n; i++ {
+			f.Add(fmt.Sprintf("key-%d", i))
+		}
+		for i := 0; i < n; i++ {
+			if key := fmt.Sprintf("key-%d", i); !f.MayContain(key) {
+				t.Fatalf("MayContain(%q) returned false for an added key", key)
+			}
+		}
+		// Allow some slack over the target since the rate is measured on a finite sample.
+		if rate := measureFalsePositiveRate(f.MayContain, 200000); rate > 1.3*p {
+			t.Errorf("p=%v: measured false positive rate %v", p, rate)
+		}
+		if rate := f.FalsePositiveRate(); rate > 1.1*p || rate < 0.9*p {
+			t.Errorf("p=%v: FalsePositiveRate() estimated %v", p, rate)
+		}
+		if f.Size() != n {
+			t.Errorf("Size(): %d, want %d", f.Size(), n)
+		}
+	}
+}
+
+func TestBloomFilter_Errors(t *testing.T) {
+	for _, test := range []struct {
+		n int
+		p float64
+	}{{0, 0.1}, {10, 0}, {10, 1}, {10, -0.5}} {
+		if _, err := NewBloomFilter(test.n, test.p); err == nil {
+			t.Errorf("NewBloomFilter(%d, %v) returned nil error, want error", test.n, test.p)
+		}
+		if _, err := NewCountingBloomFilter(test.n, test.p); err == nil {
+			t.Errorf("NewCountingBloomFilter(%d, %v) returned nil error, want error", test.n, test.p)
+		}
+	}
+}
+
+func TestBloomFilter_Union(t *testing.T) {
+	a, _ := NewBloomFilter(100, 0.01)
+	b, _ := NewBloomFilter(100, 0.01)
+	for i := 0; i < 50; i++ {
+		a.Add(fmt.Sprintf("a-%d", i))
+		b.Add(fmt.Sprintf("b-%d", i))
+	}
+	if err := a.Union(b); err != nil {
+		t.Fatalf("Union() returned unexpected error; %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if !a.MayContain(fmt.Sprintf("a-%d", i)) || !a.MayContain(fmt.Sprintf("b-%d", i)) {
+			t.Fatalf("union lost key %d", i)
+		}
+	}
+	if a.Size() != 100 {
+		t.Errorf("Size(): %d, want 100", a.Size())
+	}
+	c, _ := NewBloomFilter(1000, 0.01)
+	if err := a.Union(c); err == nil {
+		t.Error("Union() returned nil error for filters of different sizes, want error")
+	}
+}
+
+func TestBloomFilter_Serialization(t *testing.T) {
+	f, _ := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 700; i++ {
+		f.Add(fmt.Sprintf("key-%d", i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() returned unexpected error; %v", err)
+	}
+	g := new(BloomFilter)
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() returned unexpected error; %v", err)
+	}
+	if g.Size() != f.Size() || g.FalsePositiveRate() != f.FalsePositiveRate() {
+		t.Errorf("decoded filter has %d keys and rate %v, want %d and %v",
+			g.Size(), g.FalsePositiveRate(), f.Size(), f.FalsePositiveRate())
+	}
+	for i := 0; i < 2000; i++ {
+		if key := fmt.Sprintf("key-%d", i); f.MayContain(key) != g.MayContain(key) {
+			t.Fatalf("decoded filter disagrees on %q", key)
+		}
+	}
+	for _, bad := range [][]byte{nil, data[:23], data[:len(data)-1], append(data, 0)} {
+		if err := g.UnmarshalBinary(bad); err == nil {
+			t.Errorf("UnmarshalBinary() of %d bytes returned nil error, want error", len(bad))
+		}
+	}
+}
+
+func TestCountingBloomFilter(t *testing.T) {
+	const n = 5000
+	f, _ := NewCountingBloomFilter(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(fmt.Sprintf("key-%d", i))
+	}
+	// Adding a key twice requires removing it twice.
+	f.Add("key-0")
+	for i := 0; i < n; i += 2 {
+		if err := f.Remove(fmt.Sprintf("key-%d", i)); err != nil {
+			t.Fatalf("Remove() returned unexpected error; %v", err)
+		}
+	}
+	if f.Size() != n/2+1 {
+		t.Errorf("Size(): %d, want %d", f.Size(), n/2+1)
+	}
+	for i := 1; i < n; i += 2 {
+		if key := fmt.Sprintf("key-%d", i); !f.MayContain(key) {
+			t.Fatalf("MayContain(%q) returned false for a key still in the filter", key)
+		}
+	}
+	if !f.MayContain("key-0") {
+		t.Error("MayContain(\"key-0\") returned false for a key added twice and removed once")
+	}
+	// The removed keys are now as likely to be reported as any absent key.
+	removed := 0
+	for i := 2; i < n; i += 2 {
+		if f.MayContain(fmt.Sprintf("key-%d", i)) {
+			removed++
+		}
+	}
+	if rate := float64(removed) / float64(n/2); rate > 0.02 {
+		t.Errorf("removed keys are still reported at rate %v", rate)
+	}
+	if rate := measureFalsePositiveRate(f.MayContain, 100000); rate > 0.013 {
+		t.Errorf("measured false positive rate %v", rate)
+	}
+	absent := "absent-0"
+	for i := 1; f.MayContain(absent); i++ {
+		absent = fmt.Sprintf("absent-%d", i)
+	}
+	if err := f.Remove(absent); err == nil {
+		t.Errorf("Remove(%q) returned nil error for a missing key, want error", absent)
+	}
+}
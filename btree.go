@@ -0,0 +1,408 @@
+package ads
+
+import "fmt"
+
+// MapEntry is a key/value pair returned by the iterables of ordered maps.
+type MapEntry struct {
+	Key, Value interface{}
+}
+
+// bTreeNode is a node of a B+tree. Internal nodes only hold separator keys and children, where
+// children[i] holds the keys smaller than keys[i] and children[i+1] the ones greater or equal.
+// Leaves hold the entries and are linked in key order.
+type bTreeNode struct {
+	keys     []interface{}
+	values   []interface{}
+	children []*bTreeNode
+	next     *bTreeNode
+}
+
+func (n *bTreeNode) leaf() bool {
+	return n.children == nil
+}
+
+// BTree is an in-memory B+tree ordered map. Every node has at most degree children (or degree-1
+// entries for leaves) which keeps the tree shallow and its nodes cache-friendly.
+type BTree struct {
+	root   *bTreeNode
+	cmp    Comparator
+	degree int
+	length int
+}
+
+// NewBTree returns an empty B+tree ordered by the given comparator. degree must be at least 3.
+func NewBTree(cmp Comparator, degree int) (*BTree, error) {
+	if degree < 3 {
+		return nil, fmt.Errorf("tree degree must be at least 3, got %d", degree)
+	}
+	return &BTree{root: &bTreeNode{}, cmp: cmp, degree: degree}, nil
+}
+
+// NewBTreeFromSorted returns a B+tree holding the given entries, which must be sorted by key in
+// strictly increasing order. The tree is bulk loaded bottom-up in linear time.
+func NewBTreeFromSorted(cmp Comparator, degree int, entries []MapEntry) (*BTree, error) {
+	t, err := NewBTree(cmp, degree)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(entries); i++ {
+		if cmp(entries[i-1].Key, entries[i].Key) >= 0 {
+			return nil, fmt.Errorf("entries are not sorted, %v is followed by %v",
+				entries[i-1].Key, entries[i].Key)
+		}
+	}
+	if len(entries) == 0 {
+		return t, nil
+	}
+	// Entries and children are spread evenly so every node gets at least the minimum occupancy.
+	var level []*bTreeNode
+	var mins []interface{}
+	for _, chunk := range evenChunks(len(entries), t.maxKeys()) {
+		n := &bTreeNode{}
+		for _, e := range entries[chunk[0]:chunk[1]] {
+			n.keys = append(n.keys, e.Key)
+			n.values = append(n.values, e.Value)
+		}
+		if len(level) > 0 {
+			level[len(level)-1].next = n
+		}
+		level = append(level, n)
+		mins = append(mins, n.keys[0])
+	}
+	for len(level) > 1 {
+		var parents []*bTreeNode
+		var parentMins []interface{}
+		for _, chunk := range evenChunks(len(level), t.degree) {
+			p := &bTreeNode{children: append([]*bTreeNode(nil), level[chunk[0]:chunk[1]]...)}
+			p.keys = append([]interface{}(nil), mins[chunk[0]+1:chunk[1]]...)
+			parents = append(parents, p)
+			parentMins = append(parentMins, mins[chunk[0]])
+		}
+		level, mins = parents, parentMins
+	}
+	t.root = level[0]
+	t.length = len(entries)
+	return t, nil
+}
+
+// evenChunks splits n items into the fewest chunks of at most size items, balancing their sizes.
+// It returns the [start, end) bounds of each chunk.
+func evenChunks(n, size int) [][2]int {
+	count := (n + size - 1) / size
+	chunks := make([][2]int, count)
+	start := 0
+	for i := range chunks {
+		end := start + n/count
+		if i < n%count {
+			end++
+		}
+		chunks[i] = [2]int{start, end}
+		start = end
+	}
+	return chunks
+}
+
+// maxKeys is the maximum number of keys in any node.
+func (t *BTree) maxKeys() int {
+	return t.degree - 1
+}
+
+// minKeys is the minimum number of keys in any node but the root.
+func (t *BTree) minKeys() int {
+	return (t.degree - 1) / 2
+}
+
+// lowerBound returns the index of the first key of n greater than or equal to k.
+func (t *BTree) lowerBound(n *bTreeNode, k interface{}) int {
+	lo, hi := 0, len(n.keys)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if t.cmp(n.keys[mid], k) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// childIndex returns the index of the child of the internal node n that may hold k.
+func (t *BTree) childIndex(n *bTreeNode, k interface{}) int {
+	lo, hi := 0, len(n.keys)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if t.cmp(n.keys[mid], k) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// findLeaf returns the leaf that may hold k.
+func (t *BTree) findLeaf(k interface{}) *bTreeNode {
+	n := t.root
+	for !n.leaf() {
+		n = n.children[t.childIndex(n, k)]
+	}
+	return n
+}
+
+// Get the value stored in the given key. Returns nil, false if it doesn't exist.
+func (t *BTree) Get(k interface{}) (interface{}, bool) {
+	n := t.findLeaf(k)
+	if i := t.lowerBound(n, k); i < len(n.keys) && t.cmp(n.keys[i], k) == 0 {
+		return n.values[i], true
+	}
+	return nil, false
+}
+
+// Set or update a value using given key.
+func (t *BTree) Set(k, v interface{}) {
+	added, sep, right := t.insert(t.root, k, v)
+	if added {
+		t.length++
+	}
+	if right != nil {
+		t.root = &bTreeNode{keys: []interface{}{sep}, children: []*bTreeNode{t.root, right}}
+	}
+}
+
+// insert k into the subtree rooted at n. If n overflows it's split and the new right sibling is
+// returned along with the separator key to be inserted in the parent.
+func (t *BTree) insert(n *bTreeNode, k, v interface{}) (bool, interface{}, *bTreeNode) {
+	if n.leaf() {
+		i := t.lowerBound(n, k)
+		if i < len(n.keys) && t.cmp(n.keys[i], k) == 0 {
+			n.values[i] = v
+			return false, nil, nil
+		}
+		n.keys = insertAt(n.keys, i, k)
+		n.values = insertAt(n.values, i, v)
+		if len(n.keys) <= t.maxKeys() {
+			return true, nil, nil
+		}
+		mid := len(n.keys) / 2
+		right := &bTreeNode{
+			keys:   append([]interface{}(nil), n.keys[mid:]...),
+			values: append([]interface{}(nil), n.values[mid:]...),
+			next:   n.next,
+		}
+		n.keys, n.values = shrink(n.keys, mid), shrink(n.values, mid)
+		n.next = right
+		return true, right.keys[0], right
+	}
+	i := t.childIndex(n, k)
+	added, sep, child := t.insert(n.children[i], k, v)
+	if child == nil {
+		return added, nil, nil
+	}
+	n.keys = insertAt(n.keys, i, sep)
+	n.children = insertNodeAt(n.children, i+1, child)
+	if len(n.keys) <= t.maxKeys() {
+		return added, nil, nil
+	}
+	mid := len(n.keys) / 2
+	up := n.keys[mid]
+	right := &bTreeNode{
+		keys:     append([]interface{}(nil), n.keys[mid+1:]...),
+		children: append([]*bTreeNode(nil), n.children[mid+1:]...),
+	}
+	n.keys = shrink(n.keys, mid)
+	for j := mid + 1; j < len(n.children); j++ {
+		n.children[j] = nil
+	}
+	n.children = n.children[:mid+1]
+	return added, up, right
+}
+
+// Delete the entry stored at the given key (if exists).
+func (t *BTree) Delete(k interface{}) {
+	if t.delete(t.root, k) {
+		t.length--
+	}
+	if !t.root.leaf() && len(t.root.keys) == 0 {
+		t.root = t.root.children[0]
+	}
+}
+
+// delete k from the subtree rooted at n, rebalancing the children of n that underflow.
+func (t *BTree) delete(n *bTreeNode, k interface{}) bool {
+	if n.leaf() {
+		i := t.lowerBound(n, k)
+		if i == len(n.keys) || t.cmp(n.keys[i], k) != 0 {
+			return false
+		}
+		n.keys = removeAt(n.keys, i)
+		n.values = removeAt(n.values, i)
+		return true
+	}
+	i := t.childIndex(n, k)
+	if !t.delete(n.children[i], k) {
+		return false
+	}
+	if len(n.children[i].keys) < t.minKeys() {
+		t.rebalance(n, i)
+	}
+	return true
+}
+
+// rebalance the i-th child of n, which has one key less than the minimum, by borrowing a key from
+// a sibling or merging with it.
+func (t *BTree) rebalance(n *bTreeNode, i int) {
+	child := n.children[i]
+	if i > 0 && len(n.children[i-1].keys) > t.minKeys() {
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		if child.leaf() {
+			child.keys = insertAt(child.keys, 0, left.keys[last])
+			child.values = insertAt(child.values, 0, left.values[last])
+			left.values = removeAt(left.values, last)
+			n.keys[i-1] = child.keys[0]
+		} else {
+			child.keys = insertAt(child.keys, 0, n.keys[i-1])
+			child.children = insertNodeAt(child.children, 0, left.children[last+1])
+			left.children[last+1] = nil
+			left.children = left.children[:last+1]
+			n.keys[i-1] = left.keys[last]
+		}
+		left.keys = removeAt(left.keys, last)
+		return
+	}
+	if i < len(n.children)-1 && len(n.children[i+1].keys) > t.minKeys() {
+		right := n.children[i+1]
+		if child.leaf() {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.keys = removeAt(right.keys, 0)
+			right.values = removeAt(right.values, 0)
+			n.keys[i] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, n.keys[i])
+			child.children = append(child.children, right.children[0])
+			n.keys[i] = right.keys[0]
+			right.keys = removeAt(right.keys, 0)
+			copy(right.children, right.children[1:])
+			right.children[len(right.children)-1] = nil
+			right.children = right.children[:len(right.children)-1]
+		}
+		return
+	}
+	// Neither sibling can lend a key: merge the child with one of them.
+	if i == 0 {
+		i++
+	}
+	left, right := n.children[i-1], n.children[i]
+	if left.leaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, n.keys[i-1]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	n.keys = removeAt(n.keys, i-1)
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// insertAt inserts v at position i of s.
+func insertAt(s []interface{}, i int, v interface{}) []interface{} {
+	s = append(s, nil)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// insertNodeAt inserts n at position i of s.
+func insertNodeAt(s []*bTreeNode, i int, n *bTreeNode) []*bTreeNode {
+	s = append(s, nil)
+	copy(s[i+1:], s[i:])
+	s[i] = n
+	return s
+}
+
+// removeAt removes the element at position i of s.
+func removeAt(s []interface{}, i int) []interface{} {
+	copy(s[i:], s[i+1:])
+	// Avoid memory leaks (free references for garbage collector)
+	s[len(s)-1] = nil
+	return s[:len(s)-1]
+}
+
+// shrink truncates s to length n, freeing the references past it.
+func shrink(s []interface{}, n int) []interface{} {
+	for i := n; i < len(s); i++ {
+		s[i] = nil
+	}
+	return s[:n]
+}
+
+// Size returns the number of entries stored in the tree.
+func (t *BTree) Size() int {
+	return t.length
+}
+
+// Empty removes all entries from the tree.
+func (t *BTree) Empty() {
+	t.root = &bTreeNode{}
+	t.length = 0
+}
+
+// Iterator returns an iterable over the entries of the tree in key order.
+func (t *BTree) Iterator() Iterable {
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return (&BTreeIterable{t: t, n: n}).skipExhausted()
+}
+
+// Range returns an iterable over the entries with keys in the closed range [lo, hi] in key order.
+// A nil bound means the range is unbounded on that side.
+func (t *BTree) Range(lo, hi interface{}) Iterable {
+	if lo == nil {
+		it := t.Iterator().(*BTreeIterable)
+		it.hi, it.bounded = hi, hi != nil
+		return it
+	}
+	n := t.findLeaf(lo)
+	it := &BTreeIterable{t: t, n: n, i: t.lowerBound(n, lo), hi: hi, bounded: hi != nil}
+	return it.skipExhausted()
+}
+
+// BTreeIterable implements Iterable interface for BTree. It walks the linked list of leaves,
+// returning MapEntry elements.
+type BTreeIterable struct {
+	t       *BTree
+	n       *bTreeNode
+	i       int
+	hi      interface{}
+	bounded bool
+}
+
+// skipExhausted moves to the next leaf with entries when the current one has been consumed.
+func (i *BTreeIterable) skipExhausted() *BTreeIterable {
+	for i.n != nil && i.i >= len(i.n.keys) {
+		i.n, i.i = i.n.next, 0
+	}
+	return i
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *BTreeIterable) Scan() bool {
+	return i.n != nil && (!i.bounded || i.t.cmp(i.n.keys[i.i], i.hi) <= 0)
+}
+
+// Next returns the next element in the iterable.
+func (i *BTreeIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	e := MapEntry{Key: i.n.keys[i.i], Value: i.n.values[i.i]}
+	i.i++
+	i.skipExhausted()
+	return e, nil
+}
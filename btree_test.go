@@ -0,0 +1,211 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// checkBTreeInvariants verifies node occupancy, key order, separators, the depth of the leaves
+// and the leaf links of the tree.
+func checkBTreeInvariants(t *testing.T, tree *BTree) {
+	t.Helper()
+	leafDepth := -1
+	var leaves []*bTreeNode
+	var walk func(n *bTreeNode, lo, hi interface{}, depth int)
+	walk = func(n *bTreeNode, lo, hi interface{}, depth int) {
+		if n != tree.root && (len(n.keys) < tree.minKeys() || len(n.keys) > tree.maxKeys()) {
+			t.Fatalf("node %v has %d keys, want within [%d, %d]", n.keys, len(n.keys), tree.minKeys(), tree.maxKeys())
+		}
+		for i, k := range n.keys {
+			if i > 0 && tree.cmp(n.keys[i-1], k) >= 0 {
+				t.Fatalf("node keys %v are not sorted", n.keys)
+			}
+			if lo != nil && tree.cmp(k, lo) < 0 || hi != nil && tree.cmp(k, hi) >= 0 {
+				t.Fatalf("key %v is out of the separator bounds [%v, %v)", k, lo, hi)
+			}
+		}
+		if n.leaf() {
+			if len(n.keys) != len(n.values) {
+				t.Fatalf("leaf has %d keys and %d values", len(n.keys), len(n.values))
+			}
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("leaves at depths %d and %d", leafDepth, depth)
+			}
+			leaves = append(leaves, n)
+			return
+		}
+		if len(n.children) != len(n.keys)+1 {
+			t.Fatalf("internal node has %d keys and %d children", len(n.keys), len(n.children))
+		}
+		for i, c := range n.children {
+			clo, chi := lo, hi
+			if i > 0 {
+				clo = n.keys[i-1]
+			}
+			if i < len(n.keys) {
+				chi = n.keys[i]
+			}
+			walk(c, clo, chi, depth+1)
+		}
+	}
+	walk(tree.root, nil, nil, 0)
+	count := 0
+	for i, l := range leaves {
+		count += len(l.keys)
+		var next *bTreeNode
+		if i+1 < len(leaves) {
+			next = leaves[i+1]
+		}
+		if l.next != next {
+			t.Fatalf("leaf %d is not linked to the following leaf", i)
+		}
+	}
+	if count != tree.Size() {
+		t.Fatalf("Size(): %d, leaves hold %d entries", tree.Size(), count)
+	}
+}
+
+func TestNewBTree_Errors(t *testing.T) {
+	for _, d := range []int{-1, 0, 1, 2} {
+		if _, err := NewBTree(IntComparator, d); err == nil {
+			t.Errorf("NewBTree(%d) returned nil error, want error", d)
+		}
+	}
+}
+
+func TestBTree_ThroughOps(t *testing.T) {
+	for _, degree := range []int{3, 4, 5, 8, 32} {
+		t.Run(fmt.Sprintf("degree %d", degree), func(t *testing.T) {
+			const n = 1000
+			r := rand.New(rand.NewSource(int64(degree)))
+			tree, err := NewBTree(IntComparator, degree)
+			if err != nil {
+				t.Fatalf("NewBTree(%d) returned unexpected error; %v", degree, err)
+			}
+			control := map[int]int{}
+			for i := 0; i < 4*n; i++ {
+				k := r.Intn(n)
+				switch r.Intn(3) {
+				case 0:
+					tree.Delete(k)
+					delete(control, k)
+				default:
+					tree.Set(k, i)
+					control[k] = i
+				}
+				checkBTreeInvariants(t, tree)
+				got, ok := tree.Get(k)
+				want, wantOk := control[k]
+				if ok != wantOk || (ok && got != want) {
+					t.Fatalf("Get(%d): %v, %v, want %v, %v", k, got, ok, want, wantOk)
+				}
+			}
+			want := []interface{}{}
+			for k := 0; k < n; k++ {
+				if v, ok := control[k]; ok {
+					want = append(want, MapEntry{Key: k, Value: v})
+				}
+			}
+			if diff := cmp.Diff(want, iterableToSlice(t, tree.Iterator())); diff != "" {
+				t.Fatalf("Iterator() produced unwanted entries (-want +got):\n%s", diff)
+			}
+			for k := range control {
+				tree.Delete(k)
+				checkBTreeInvariants(t, tree)
+			}
+			if tree.Size() != 0 {
+				t.Errorf("Size(): %d after deleting every key", tree.Size())
+			}
+		})
+	}
+}
+
+func TestBTree_Range(t *testing.T) {
+	tree, _ := NewBTree(IntComparator, 4)
+	for k := 0; k < 100; k += 5 {
+		tree.Set(k, k*10)
+	}
+	tests := []struct {
+		lo, hi interface{}
+		want   []int
+	}{
+		{lo: 10, hi: 25, want: []int{10, 15, 20, 25}},
+		{lo: 11, hi: 24, want: []int{15, 20}},
+		{lo: -10, hi: 3, want: []int{0}},
+		{lo: 93, hi: 200, want: []int{95}},
+		{lo: 96, hi: 200, want: []int{}},
+		{lo: 11, hi: 14, want: []int{}},
+		{lo: 30, hi: 20, want: []int{}},
+		// Nil bounds leave the range open on that side.
+		{lo: nil, hi: 12, want: []int{0, 5, 10}},
+		{lo: 82, hi: nil, want: []int{85, 90, 95}},
+		{lo: nil, hi: -1, want: []int{}},
+	}
+	for _, test := range tests {
+		want := []interface{}{}
+		for _, k := range test.want {
+			want = append(want, MapEntry{Key: k, Value: k * 10})
+		}
+		if diff := cmp.Diff(want, iterableToSlice(t, tree.Range(test.lo, test.hi))); diff != "" {
+			t.Errorf("Range(%v, %v) produced unwanted entries (-want +got):\n%s", test.lo, test.hi, diff)
+		}
+	}
+	tree.Empty()
+	if tree.Size() != 0 || tree.Iterator().Scan() {
+		t.Error("Empty() left entries in the tree")
+	}
+}
+
+func TestNewBTreeFromSorted(t *testing.T) {
+	for _, degree := range []int{3, 4, 7} {
+		for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+			t.Run(fmt.Sprintf("degree %d/n = %d", degree, n), func(t *testing.T) {
+				entries := make([]MapEntry, n)
+				want := make([]interface{}, n)
+				for i := range entries {
+					entries[i] = MapEntry{Key: i * 2, Value: fmt.Sprint(i)}
+					want[i] = entries[i]
+				}
+				tree, err := NewBTreeFromSorted(IntComparator, degree, entries)
+				if err != nil {
+					t.Fatalf("NewBTreeFromSorted() returned unexpected error; %v", err)
+				}
+				checkBTreeInvariants(t, tree)
+				if diff := cmp.Diff(want, iterableToSlice(t, tree.Iterator())); diff != "" {
+					t.Fatalf("Iterator() produced unwanted entries (-want +got):\n%s", diff)
+				}
+				// The bulk loaded tree supports regular updates.
+				for i := 0; i < n; i++ {
+					tree.Set(i*2+1, nil)
+					checkBTreeInvariants(t, tree)
+				}
+				for i := 0; i < n; i++ {
+					tree.Delete(i * 2)
+					checkBTreeInvariants(t, tree)
+				}
+				if tree.Size() != n {
+					t.Errorf("Size(): %d, want %d", tree.Size(), n)
+				}
+			})
+		}
+	}
+}
+
+func TestNewBTreeFromSorted_Errors(t *testing.T) {
+	unsorted := []MapEntry{{Key: 1}, {Key: 3}, {Key: 2}}
+	if _, err := NewBTreeFromSorted(IntComparator, 4, unsorted); err == nil {
+		t.Error("NewBTreeFromSorted() with unsorted entries returned nil error, want error")
+	}
+	duplicated := []MapEntry{{Key: 1}, {Key: 1}}
+	if _, err := NewBTreeFromSorted(IntComparator, 4, duplicated); err == nil {
+		t.Error("NewBTreeFromSorted() with duplicated keys returned nil error, want error")
+	}
+	if _, err := NewBTreeFromSorted(IntComparator, 2, nil); err == nil {
+		t.Error("NewBTreeFromSorted() with invalid degree returned nil error, want error")
+	}
+}
@@ -0,0 +1,31 @@
+package ads
+
+// Comparator establishes the order of the elements of ordered containers. It returns a negative
+// number if a < b, zero if a == b and a positive number if a > b.
+type Comparator func(a, b interface{}) int
+
+// IntComparator compares two int values.
+func IntComparator(a, b interface{}) int {
+	x, y := a.(int), b.(int)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// StringComparator compares two string values lexicographically.
+func StringComparator(a, b interface{}) int {
+	x, y := a.(string), b.(string)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
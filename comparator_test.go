@@ -0,0 +1,26 @@
+package ads
+
+import "testing"
+
+func TestComparators(t *testing.T) {
+	tests := []struct {
+		name string
+		cmp  Comparator
+		a, b interface{}
+		want int
+	}{
+		{name: "int less", cmp: IntComparator, a: 1, b: 2, want: -1},
+		{name: "int equal", cmp: IntComparator, a: 2, b: 2, want: 0},
+		{name: "int greater", cmp: IntComparator, a: 3, b: -2, want: 1},
+		{name: "string less", cmp: StringComparator, a: "a", b: "ab", want: -1},
+		{name: "string equal", cmp: StringComparator, a: "ab", b: "ab", want: 0},
+		{name: "string greater", cmp: StringComparator, a: "b", b: "ab", want: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.cmp(test.a, test.b); got != test.want {
+				t.Errorf("cmp(%v, %v): %d, want %d", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// markableRef is an immutable (successor, deleted mark) pair. Nodes reference their successors
+// through markableRefs so both fields can be replaced together with a single compare-and-swap.
+type markableRef struct {
+	node   *concurrentSkipListNode
+	marked bool
+}
+
+// valueBox wraps the values stored in atomic.Value, which requires a consistent concrete type.
+type valueBox struct {
+	v interface{}
+}
+
+// concurrentSkipListNode holds an entry and its forward references, stored as *markableRef.
+type concurrentSkipListNode struct {
+	key   interface{}
+	value atomic.Value
+	next  []unsafe.Pointer
+}
+
+func newConcurrentSkipListNode(k, v interface{}, levels int) *concurrentSkipListNode {
+	n := &concurrentSkipListNode{key: k, next: make([]unsafe.Pointer, levels)}
+	n.value.Store(valueBox{v: v})
+	for i := range n.next {
+		n.next[i] = unsafe.Pointer(&markableRef{})
+	}
+	return n
+}
+
+// load returns the successor of n at the given level and whether n is marked as deleted there.
+func (n *concurrentSkipListNode) load(level int) (*concurrentSkipListNode, bool) {
+	r := (*markableRef)(atomic.LoadPointer(&n.next[level]))
+	return r.node, r.marked
+}
+
+// cas atomically replaces the (successor, mark) pair of n at the given level if it still matches
+// the expected one.
+func (n *concurrentSkipListNode) cas(level int, old *concurrentSkipListNode, oldMark bool,
+	new *concurrentSkipListNode, newMark bool) bool {
+	cur := atomic.LoadPointer(&n.next[level])
+	if r := (*markableRef)(cur); r.node != old || r.marked != oldMark {
+		return false
+	}
+	return atomic.CompareAndSwapPointer(&n.next[level], cur, unsafe.Pointer(&markableRef{node: new, marked: newMark}))
+}
+
+// ConcurrentSkipList is a lock-free ordered map safe for concurrent use by multiple goroutines.
+// Entries are removed by first marking their references (logical deletion) and then unlinking
+// them, which any traversal helps to complete. See The Art of Multiprocessor Programming
+// (Herlihy & Shavit), chapter 14 for reference.
+type ConcurrentSkipList struct {
+	head     *concurrentSkipListNode
+	cmp      Comparator
+	p        float64
+	maxLevel int
+	length   int64
+	// rng is only used to draw node levels; rand.Rand isn't safe for concurrent use.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+// NewConcurrentSkipList returns an empty concurrent skip list ordered by the given comparator.
+// Entries are promoted with probability p up to maxLevel levels, using a random source
+// initialized with seed.
+func NewConcurrentSkipList(cmp Comparator, maxLevel int, p float64, seed int64) (*ConcurrentSkipList, error) {
+	if err := validateSkipListParams(maxLevel, p); err != nil {
+		return nil, err
+	}
+	return &ConcurrentSkipList{
+		head:     newConcurrentSkipListNode(nil, nil, maxLevel),
+		cmp:      cmp,
+		p:        p,
+		maxLevel: maxLevel,
+		rng:      rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// randomLevel returns the number of levels of a new node.
+func (s *ConcurrentSkipList) randomLevel() int {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	l := 1
+	for l < s.maxLevel && s.rng.Float64() < s.p {
+		l++
+	}
+	return l
+}
+
+// find fills preds and succs with, for every level, the last node whose key is smaller than k
+// and its successor, unlinking the marked nodes found along the way. It returns whether k is
+// stored.
+func (s *ConcurrentSkipList) find(k interface{}, preds, succs []*concurrentSkipListNode) bool {
+retry:
+	pred := s.head
+	var curr *concurrentSkipListNode
+	for level := s.maxLevel - 1; level >= 0; level-- {
+		curr, _ = pred.load(level)
+		for curr != nil {
+			succ, marked := curr.load(level)
+			for marked {
+				if !pred.cas(level, curr, false, succ, false) {
+					goto retry
+				}
+				if curr, _ = pred.load(level); curr == nil {
+					break
+				}
+				succ, marked = curr.load(level)
+			}
+			if curr == nil || s.cmp(curr.key, k) >= 0 {
+				break
+			}
+			pred, curr = curr, succ
+		}
+		preds[level], succs[level] = pred, curr
+	}
+	return curr != nil && s.cmp(curr.key, k) == 0
+}
+
+// Get the value stored in the given key. Returns nil, false if it doesn't exist. Get never
+// modifies the list, so it doesn't block nor retry.
+func (s *ConcurrentSkipList) Get(k interface{}) (interface{}, bool) {
+	pred := s.head
+	var curr *concurrentSkipListNode
+	for level := s.maxLevel - 1; level >= 0; level-- {
+		curr, _ = pred.load(level)
+		for curr != nil {
+			succ, marked := curr.load(level)
+			for marked && succ != nil {
+				curr = succ
+				succ, marked = curr.load(level)
+			}
+			if marked {
+				curr = nil
+				break
+			}
+			if s.cmp(curr.key, k) >= 0 {
+				break
+			}
+			pred, curr = curr, succ
+		}
+	}
+	if curr == nil || s.cmp(curr.key, k) != 0 {
+		return nil, false
+	}
+	return curr.value.Load().(valueBox).v, true
+}
+
+// Set or update a value using given key.
+func (s *ConcurrentSkipList) Set(k, v interface{}) {
+	top := s.randomLevel()
+	preds := make([]*concurrentSkipListNode, s.maxLevel)
+	succs := make([]*concurrentSkipListNode, s.maxLevel)
+	for {
+		if s.find(k, preds, succs) {
+			succs[0].value.Store(valueBox{v: v})
+			// If the node was removed meanwhile the value may be lost with it, so the key is
+			// inserted again.
+			if _, marked := succs[0].load(0); marked {
+				continue
+			}
+			return
+		}
+		n := newConcurrentSkipListNode(k, v, top)
+		for level := 0; level < top; level++ {
+			n.next[level] = unsafe.Pointer(&markableRef{node: succs[level]})
+		}
+		// Linking the bottom level is the linearization point of the insertion.
+		if !preds[0].cas(0, succs[0], false, n, false) {
+			continue
+		}
+		atomic.AddInt64(&s.length, 1)
+		for level := 1; level < top; level++ {
+			for {
+				next, marked := n.load(level)
+				if marked {
+					// The node is being removed concurrently, stop linking it.
+					return
+				}
+				if next != succs[level] && !n.cas(level, next, false, succs[level], false) {
+					continue
+				}
+				if preds[level].cas(level, succs[level], false, n, false) {
+					break
+				}
+				s.find(k, preds, succs)
+			}
+		}
+		return
+	}
+}
+
+// Remove the entry stored at the given key (if exists).
+func (s *ConcurrentSkipList) Remove(k interface{}) {
+	preds := make([]*concurrentSkipListNode, s.maxLevel)
+	succs := make([]*concurrentSkipListNode, s.maxLevel)
+	if !s.find(k, preds, succs) {
+		return
+	}
+	n := succs[0]
+	// Mark the upper levels first so the node stops being reachable from above.
+	for level := len(n.next) - 1; level >= 1; level-- {
+		succ, marked := n.load(level)
+		for !marked {
+			n.cas(level, succ, false, succ, true)
+			succ, marked = n.load(level)
+		}
+	}
+	succ, _ := n.load(0)
+	for {
+		// Marking the bottom level is the linearization point of the removal.
+		if n.cas(0, succ, false, succ, true) {
+			atomic.AddInt64(&s.length, -1)
+			s.find(k, preds, succs)
+			return
+		}
+		var marked bool
+		if succ, marked = n.load(0); marked {
+			// Another goroutine removed it first.
+			return
+		}
+	}
+}
+
+// Size returns the number of entries stored in the skip list.
+func (s *ConcurrentSkipList) Size() int {
+	return int(atomic.LoadInt64(&s.length))
+}
+
+// Iterator returns an iterable over the entries of the skip list in key order. The iterable is
+// weakly consistent: it reflects some of the updates made after its creation.
+func (s *ConcurrentSkipList) Iterator() Iterable {
+	it := &ConcurrentSkipListIterable{}
+	it.n, _ = s.head.load(0)
+	it.skipMarked()
+	return it
+}
+
+// ConcurrentSkipListIterable implements Iterable interface for ConcurrentSkipList, returning
+// MapEntry elements.
+type ConcurrentSkipListIterable struct {
+	n *concurrentSkipListNode
+}
+
+// skipMarked advances the iterable past the entries that have been removed.
+func (i *ConcurrentSkipListIterable) skipMarked() {
+	for i.n != nil {
+		next, marked := i.n.load(0)
+		if !marked {
+			return
+		}
+		i.n = next
+	}
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *ConcurrentSkipListIterable) Scan() bool {
+	return i.n != nil
+}
+
+// Next returns the next element in the iterable.
+func (i *ConcurrentSkipListIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	e := MapEntry{Key: i.n.key, Value: i.n.value.Load().(valueBox).v}
+	i.n, _ = i.n.load(0)
+	i.skipMarked()
+	return e, nil
+}
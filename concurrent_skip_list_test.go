@@ -0,0 +1,120 @@
+package ads
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConcurrentSkipList_ThroughOps(t *testing.T) {
+	const n = 500
+	r := rand.New(rand.NewSource(1))
+	s, err := NewConcurrentSkipList(IntComparator, 16, 0.5, 1)
+	if err != nil {
+		t.Fatalf("NewConcurrentSkipList() returned unexpected error; %v", err)
+	}
+	control := map[int]int{}
+	for i := 0; i < 4*n; i++ {
+		k := r.Intn(n)
+		if r.Intn(3) == 0 {
+			s.Remove(k)
+			delete(control, k)
+		} else {
+			s.Set(k, i)
+			control[k] = i
+		}
+		got, ok := s.Get(k)
+		want, wantOk := control[k]
+		if ok != wantOk || (ok && got != want) {
+			t.Fatalf("Get(%d): %v, %v, want %v, %v", k, got, ok, want, wantOk)
+		}
+		if s.Size() != len(control) {
+			t.Fatalf("Size(): %d, want %d", s.Size(), len(control))
+		}
+	}
+	want := []interface{}{}
+	for k := 0; k < n; k++ {
+		if v, ok := control[k]; ok {
+			want = append(want, MapEntry{Key: k, Value: v})
+		}
+	}
+	if diff := cmp.Diff(want, iterableToSlice(t, s.Iterator())); diff != "" {
+		t.Fatalf("Iterator() produced unwanted entries (-want +got):\n%s", diff)
+	}
+}
+
+func TestConcurrentSkipList_Concurrency(t *testing.T) {
+	const workers, perWorker = 8, 500
+	s, _ := NewConcurrentSkipList(IntComparator, 16, 0.5, 1)
+	var wg sync.WaitGroup
+	// Every worker owns the keys congruent to its id, inserts all of them and removes the odd
+	// multiples, while also reading the keys of the other workers.
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < perWorker; i++ {
+				s.Set(i*workers+w, w)
+				s.Get(r.Intn(workers * perWorker))
+			}
+			for i := 1; i < perWorker; i += 2 {
+				s.Remove(i*workers + w)
+				s.Get(r.Intn(workers * perWorker))
+			}
+		}(w)
+	}
+	wg.Wait()
+	want := []interface{}{}
+	for k := 0; k < workers*perWorker; k++ {
+		if (k/workers)%2 == 0 {
+			want = append(want, MapEntry{Key: k, Value: k % workers})
+		}
+	}
+	if diff := cmp.Diff(want, iterableToSlice(t, s.Iterator())); diff != "" {
+		t.Fatalf("Iterator() produced unwanted entries (-want +got):\n%s", diff)
+	}
+	if s.Size() != len(want) {
+		t.Errorf("Size(): %d, want %d", s.Size(), len(want))
+	}
+}
+
+func TestConcurrentSkipList_ContendedKeys(t *testing.T) {
+	const workers, ops, keys = 8, 2000, 64
+	s, _ := NewConcurrentSkipList(IntComparator, 8, 0.5, 1)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < ops; i++ {
+				k := r.Intn(keys)
+				if r.Intn(2) == 0 {
+					s.Set(k, k)
+				} else {
+					s.Remove(k)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	got := iterableToSlice(t, s.Iterator())
+	for i := range got {
+		e := got[i].(MapEntry)
+		if e.Key != e.Value {
+			t.Fatalf("entry %v has an unexpected value", e)
+		}
+		if i > 0 && got[i-1].(MapEntry).Key.(int) >= e.Key.(int) {
+			t.Fatalf("keys %v and %v are not sorted", got[i-1], e)
+		}
+		if _, ok := s.Get(e.Key); !ok {
+			t.Fatalf("Get(%v) didn't find an iterated entry", e.Key)
+		}
+	}
+	if s.Size() != len(got) {
+		t.Errorf("Size(): %d, iterated %d entries", s.Size(), len(got))
+	}
+}
@@ -0,0 +1,266 @@
+package ads
+
+import "sort"
+
+// Transpose returns a copy of a directed graph with every edge reversed. Undirected graphs are
+// copied as they are.
+func (g *Graph) Transpose() *Graph {
+	t := NewGraph(len(g.adj), g.flags)
+	for it := g.Edges(); it.Scan(); {
+		e := graphNextEdge(it)
+		if g.Directed() {
+			t.addEdge(e.To, e.From, e.Weight)
+		} else {
+			t.addEdge(e.From, e.To, e.Weight)
+		}
+	}
+	return t
+}
+
+// TarjanSCC labels each vertex of a directed graph with the index of its strongly connected
+// component and returns the number of components. Components are numbered in topological order
+// of the condensation: edges between components go from smaller to larger indices. It runs a
+// single depth-first search, using explicit stacks for both the search and the vertices whose
+// component isn't known yet.
+func (g *Graph) TarjanSCC() ([]int, int, error) {
+	if err := g.validateDirected(); err != nil {
+		return nil, 0, err
+	}
+	n := len(g.adj)
+	index, low := make([]int, n), make([]int, n)
+	onStack := make([]bool, n)
+	component := make([]int, n)
+	for v := range index {
+		index[v] = -1
+	}
+	counter, count := 0, 0
+	dfs, pending := NewArrayBasedStack(uint(n)), NewArrayBasedStack(uint(n))
+	visit := func(v int) {
+		index[v], low[v] = counter, counter
+		counter++
+		pending.Push(v)
+		onStack[v] = true
+		dfs.Push(&dfsFrame{v: v, edges: g.adj[v].Iterator()})
+	}
+	for src := range g.adj {
+		if index[src] != -1 {
+			continue
+		}
+		visit(src)
+		for dfs.Size() > 0 {
+			top, _ := dfs.Top()
+			f := top.(*dfsFrame)
+			if f.edges.Scan() {
+				switch e := graphNextEdge(f.edges); {
+				case index[e.To] == -1:
+					visit(e.To)
+				case onStack[e.To] && index[e.To] < low[f.v]:
+					low[f.v] = index[e.To]
+				}
+				continue
+			}
+			dfs.Pop()
+			if low[f.v] == index[f.v] {
+				// f.v is the root of a component made of the vertices above it in pending.
+				for {
+					w, _ := pending.Pop()
+					onStack[w.(int)] = false
+					component[w.(int)] = count
+					if w.(int) == f.v {
+						break
+					}
+				}
+				count++
+			}
+			if parent, err := dfs.Top(); err == nil {
+				if p := parent.(*dfsFrame).v; low[f.v] < low[p] {
+					low[p] = low[f.v]
+				}
+			}
+		}
+	}
+	// Components are found in reverse topological order.
+	for v := range component {
+		component[v] = count - 1 - component[v]
+	}
+	return component, count, nil
+}
+
+// KosarajuSCC labels each vertex of a directed graph with the index of its strongly connected
+// component and returns the number of components. Components are numbered in topological order
+// of the condensation: edges between components go from smaller to larger indices. It runs a
+// depth-first search to order the vertices by finishing time, and then searches the transposed
+// graph in decreasing finishing time, one component per tree.
+func (g *Graph) KosarajuSCC() ([]int, int, error) {
+	if err := g.validateDirected(); err != nil {
+		return nil, 0, err
+	}
+	n := len(g.adj)
+	visited := make([]bool, n)
+	finished := make([]int, 0, n)
+	s := NewArrayBasedStack(uint(n))
+	for src := range g.adj {
+		if visited[src] {
+			continue
+		}
+		visited[src] = true
+		s.Push(&dfsFrame{v: src, edges: g.adj[src].Iterator()})
+		for s.Size() > 0 {
+			top, _ := s.Top()
+			f := top.(*dfsFrame)
+			if !f.edges.Scan() {
+				finished = append(finished, f.v)
+				s.Pop()
+				continue
+			}
+			if e := graphNextEdge(f.edges); !visited[e.To] {
+				visited[e.To] = true
+				s.Push(&dfsFrame{v: e.To, edges: g.adj[e.To].Iterator()})
+			}
+		}
+	}
+	t := g.Transpose()
+	component := make([]int, n)
+	trees := newTraversal(n)
+	count := 0
+	for i := n - 1; i >= 0; i-- {
+		if v := finished[i]; !trees.Visited(v) {
+			first := trees.order.Size()
+			t.dfs(v, trees)
+			for j := first; j < trees.order.Size(); j++ {
+				w, _ := trees.order.Get(j)
+				component[w.(int)] = count
+			}
+			count++
+		}
+	}
+	return component, count, nil
+}
+
+// Condensation returns the DAG obtained by contracting each strongly connected component of a
+// directed graph into a single vertex, along with the component of each vertex. Vertex i of the
+// DAG is component i, components are numbered in topological order, and there's one edge between
+// components whenever the graph has at least one.
+func (g *Graph) Condensation() (*Graph, []int, error) {
+	component, count, err := g.TarjanSCC()
+	if err != nil {
+		return nil, nil, err
+	}
+	dag := NewGraph(count, Directed|g.flags&ArrayAdjacency)
+	seen := map[[2]int]bool{}
+	for it := g.Edges(); it.Scan(); {
+		e := graphNextEdge(it)
+		key := [2]int{component[e.From], component[e.To]}
+		if key[0] != key[1] && !seen[key] {
+			seen[key] = true
+			dag.addEdge(key[0], key[1], 1)
+		}
+	}
+	return dag, component, nil
+}
+
+// lowLinks runs a depth-first search over an undirected graph computing, for each vertex, the
+// earliest discovered vertex reachable from its subtree through one back edge. It returns the
+// bridges and the articulation points of the graph.
+func (g *Graph) lowLinks() ([]Edge, []int) {
+	n := len(g.adj)
+	disc, low := make([]int, n), make([]int, n)
+	// parentEdge is the tree edge used to discover each vertex.
+	parentEdge := make([]Edge, n)
+	// skipped tracks whether the edge back to the DFS parent was already ignored once, so parallel
+	// edges count as back edges.
+	skipped := make([]bool, n)
+	articulation := make([]bool, n)
+	for v := range disc {
+		disc[v] = -1
+	}
+	var bridges []Edge
+	counter := 0
+	s := NewArrayBasedStack(uint(n))
+	for root := range g.adj {
+		if disc[root] != -1 {
+			continue
+		}
+		disc[root], low[root] = counter, counter
+		counter++
+		s.Push(&dfsFrame{v: root, edges: g.adj[root].Iterator()})
+		children := 0
+		for s.Size() > 0 {
+			top, _ := s.Top()
+			f := top.(*dfsFrame)
+			if f.edges.Scan() {
+				e := graphNextEdge(f.edges)
+				switch {
+				case f.v != root && e.To == parentEdge[f.v].From && !skipped[f.v]:
+					skipped[f.v] = true
+				case disc[e.To] == -1:
+					disc[e.To], low[e.To] = counter, counter
+					counter++
+					parentEdge[e.To] = e
+					if f.v == root {
+						children++
+					}
+					s.Push(&dfsFrame{v: e.To, edges: g.adj[e.To].Iterator()})
+				case disc[e.To] < low[f.v]:
+					low[f.v] = disc[e.To]
+				}
+				continue
+			}
+			s.Pop()
+			if f.v == root {
+				continue
+			}
+			p := parentEdge[f.v].From
+			if low[f.v] < low[p] {
+				low[p] = low[f.v]
+			}
+			// No back edge from the subtree of f.v climbs above p.
+			if low[f.v] > disc[p] {
+				bridges = append(bridges, parentEdge[f.v])
+			}
+			if p != root && low[f.v] >= disc[p] {
+				articulation[p] = true
+			}
+		}
+		// The root splits the graph when it has several DFS subtrees.
+		articulation[root] = children > 1
+	}
+	var points []int
+	for v, ok := range articulation {
+		if ok {
+			points = append(points, v)
+		}
+	}
+	return bridges, points
+}
+
+// Bridges returns the edges of an undirected graph whose removal increases the number of
+// connected components, sorted by their endpoints. Each bridge is reported from its smallest
+// endpoint. It uses Tarjan's low-link values computed with an explicit DFS stack.
+func (g *Graph) Bridges() ([]Edge, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, err
+	}
+	bridges, _ := g.lowLinks()
+	for i, e := range bridges {
+		if e.From > e.To {
+			bridges[i] = Edge{From: e.To, To: e.From, Weight: e.Weight}
+		}
+	}
+	sort.Slice(bridges, func(i, j int) bool {
+		a, b := bridges[i], bridges[j]
+		return a.From < b.From || (a.From == b.From && a.To < b.To)
+	})
+	return bridges, nil
+}
+
+// ArticulationPoints returns, in increasing order, the vertices of an undirected graph whose
+// removal increases the number of connected components. It uses Tarjan's low-link values
+// computed with an explicit DFS stack.
+func (g *Graph) ArticulationPoints() ([]int, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, err
+	}
+	_, points := g.lowLinks()
+	return points, nil
+}
@@ -0,0 +1,219 @@
+package ads
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// sccAlgorithms lists every strongly connected components implementation.
+var sccAlgorithms = []struct {
+	name string
+	run  func(g *Graph) ([]int, int, error)
+}{
+	{name: "tarjan", run: (*Graph).TarjanSCC},
+	{name: "kosaraju", run: (*Graph).KosarajuSCC},
+}
+
+// checkSCC verifies that component labels u and v alike iff they reach each other, and that
+// components are numbered in topological order.
+func checkSCC(t *testing.T, g *Graph, component []int, count int) {
+	t.Helper()
+	reach := make([]*Traversal, g.Order())
+	for v := range reach {
+		reach[v], _ = g.BFS(v)
+	}
+	labels := map[int]bool{}
+	for u := 0; u < g.Order(); u++ {
+		labels[component[u]] = true
+		for v := 0; v < g.Order(); v++ {
+			if want := reach[u].Visited(v) && reach[v].Visited(u); (component[u] == component[v]) != want {
+				t.Fatalf("vertices %d and %d in components %d and %d, want same component: %v",
+					u, v, component[u], component[v], want)
+			}
+		}
+	}
+	if len(labels) != count {
+		t.Fatalf("count: %d, found %d distinct components", count, len(labels))
+	}
+	for it := g.Edges(); it.Scan(); {
+		if e := graphNextEdge(it); component[e.From] > component[e.To] {
+			t.Fatalf("edge %d -> %d goes from component %d back to %d",
+				e.From, e.To, component[e.From], component[e.To])
+		}
+	}
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	// CLRS, figure 22.9: a=0, b=1, ..., h=7.
+	g := newTestGraph(t, 8, Directed, [][2]int{
+		{0, 1}, {1, 2}, {1, 4}, {1, 5}, {2, 3}, {2, 6}, {3, 2}, {3, 7},
+		{4, 0}, {4, 5}, {5, 6}, {6, 5}, {6, 7}, {7, 7},
+	})
+	for _, a := range sccAlgorithms {
+		t.Run(a.name, func(t *testing.T) {
+			component, count, err := a.run(g)
+			if err != nil {
+				t.Fatalf("returned unexpected error; %v", err)
+			}
+			if diff := cmp.Diff([]int{0, 0, 1, 1, 0, 2, 2, 3}, component); diff != "" || count != 4 {
+				t.Errorf("%d components, labels mismatch (-want +got):\n%s", count, diff)
+			}
+			if _, _, err := a.run(NewGraph(1, 0)); err == nil {
+				t.Error("returned nil error for an undirected graph, want error")
+			}
+		})
+	}
+	dag, component, err := g.Condensation()
+	if err != nil {
+		t.Fatalf("Condensation() returned unexpected error; %v", err)
+	}
+	want := []interface{}{
+		Edge{From: 0, To: 1, Weight: 1}, Edge{From: 0, To: 2, Weight: 1},
+		Edge{From: 1, To: 2, Weight: 1}, Edge{From: 1, To: 3, Weight: 1}, Edge{From: 2, To: 3, Weight: 1},
+	}
+	if diff := cmp.Diff(want, iterableToSlice(t, dag.Edges())); diff != "" {
+		t.Errorf("Condensation() produced unwanted edges (-want +got):\n%s", diff)
+	}
+	if dag.Order() != 4 || len(component) != 8 {
+		t.Errorf("Condensation(): %d vertices and %d labels, want 4 and 8", dag.Order(), len(component))
+	}
+}
+
+func TestGraph_StronglyConnectedComponentsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(20)
+		g := randomGraph(r, n, r.Intn(3*n), 1, Directed)
+		for _, a := range sccAlgorithms {
+			component, count, err := a.run(g)
+			if err != nil {
+				t.Fatalf("%s returned unexpected error; %v", a.name, err)
+			}
+			checkSCC(t, g, component, count)
+		}
+		dag, _, _ := g.Condensation()
+		for it := dag.Edges(); it.Scan(); {
+			if e := graphNextEdge(it); e.From >= e.To {
+				t.Fatalf("Condensation() has edge %d -> %d against the component order", e.From, e.To)
+			}
+		}
+	}
+}
+
+func TestGraph_Transpose(t *testing.T) {
+	g := newWeightedTestGraph(t, 3, Directed, [][3]int{{0, 1, 5}, {1, 2, -1}, {2, 2, 3}})
+	want := []interface{}{
+		Edge{From: 1, To: 0, Weight: 5}, Edge{From: 2, To: 1, Weight: -1}, Edge{From: 2, To: 2, Weight: 3},
+	}
+	if diff := cmp.Diff(want, iterableToSlice(t, g.Transpose().Edges())); diff != "" {
+		t.Errorf("Transpose() produced unwanted edges (-want +got):\n%s", diff)
+	}
+}
+
+func TestGraph_BridgesAndArticulationPoints(t *testing.T) {
+	//  0 - 1 - 2 - 3     6 = 7
+	//   \ /    |
+	//    4     5
+	g := newTestGraph(t, 8, 0, [][2]int{{0, 1}, {1, 2}, {2, 3}, {1, 4}, {4, 0}, {2, 5}, {6, 7}, {7, 6}})
+	bridges, err := g.Bridges()
+	if err != nil {
+		t.Fatalf("Bridges() returned unexpected error; %v", err)
+	}
+	want := []Edge{{From: 1, To: 2, Weight: 1}, {From: 2, To: 3, Weight: 1}, {From: 2, To: 5, Weight: 1}}
+	if diff := cmp.Diff(want, bridges); diff != "" {
+		t.Errorf("Bridges() mismatch (-want +got):\n%s", diff)
+	}
+	points, err := g.ArticulationPoints()
+	if err != nil {
+		t.Fatalf("ArticulationPoints() returned unexpected error; %v", err)
+	}
+	if diff := cmp.Diff([]int{1, 2}, points); diff != "" {
+		t.Errorf("ArticulationPoints() mismatch (-want +got):\n%s", diff)
+	}
+	directed := NewGraph(2, Directed)
+	if _, err := directed.Bridges(); err == nil {
+		t.Error("Bridges() returned nil error for a directed graph, want error")
+	}
+	if _, err := directed.ArticulationPoints(); err == nil {
+		t.Error("ArticulationPoints() returned nil error for a directed graph, want error")
+	}
+}
+
+func TestGraph_BridgesAndArticulationPointsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(15)
+		g := randomGraph(r, n, r.Intn(2*n), 1, 0)
+		edges := g.graphEdges()
+		_, components := g.ConnectedComponents()
+		// An edge is a bridge iff removing it (and only it) adds a component.
+		var wantBridges []Edge
+		for i, e := range edges {
+			h := NewGraph(n, 0)
+			for j, f := range edges {
+				if i != j {
+					h.AddEdge(f.From, f.To)
+				}
+			}
+			if _, c := h.ConnectedComponents(); c > components {
+				wantBridges = append(wantBridges, e)
+			}
+		}
+		// A vertex is an articulation point iff removing its edges adds more than one component:
+		// the vertex itself becomes isolated.
+		var wantPoints []int
+		for v := 0; v < n; v++ {
+			h := NewGraph(n, 0)
+			degree := 0
+			for _, f := range edges {
+				if f.From != v && f.To != v {
+					h.AddEdge(f.From, f.To)
+				} else if f.From != f.To {
+					degree++
+				}
+			}
+			if _, c := h.ConnectedComponents(); degree > 0 && c > components+1 {
+				wantPoints = append(wantPoints, v)
+			}
+		}
+		sort.Slice(wantBridges, func(i, j int) bool {
+			a, b := wantBridges[i], wantBridges[j]
+			return a.From < b.From || (a.From == b.From && a.To < b.To)
+		})
+		bridges, _ := g.Bridges()
+		if diff := cmp.Diff(wantBridges, bridges); diff != "" {
+			t.Fatalf("Bridges() mismatch (-want +got):\n%s", diff)
+		}
+		points, _ := g.ArticulationPoints()
+		if diff := cmp.Diff(wantPoints, points); diff != "" {
+			t.Fatalf("ArticulationPoints() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestGraph_DeepSearches(t *testing.T) {
+	// A path long enough to overflow a recursive DFS with a small stack.
+	const n = 200000
+	g := NewGraph(n, Directed)
+	u := NewGraph(n, 0)
+	for v := 0; v+1 < n; v++ {
+		g.AddEdge(v, v+1)
+		u.AddEdge(v, v+1)
+	}
+	g.AddEdge(n-1, 0)
+	if _, count, _ := g.TarjanSCC(); count != 1 {
+		t.Errorf("TarjanSCC(): %d components, want 1", count)
+	}
+	if _, count, _ := g.KosarajuSCC(); count != 1 {
+		t.Errorf("KosarajuSCC(): %d components, want 1", count)
+	}
+	if bridges, _ := u.Bridges(); len(bridges) != n-1 {
+		t.Errorf("Bridges(): %d bridges, want %d", len(bridges), n-1)
+	}
+	if points, _ := u.ArticulationPoints(); len(points) != n-2 {
+		t.Errorf("ArticulationPoints(): %d points, want %d", len(points), n-2)
+	}
+}
@@ -19,4 +19,20 @@ func TestContainer_Satisfaction(t *testing.T) {
 	// Doubly Linked list
 	ll := NewList()
 	logContainerSatisfaction(t, "Doubly Linked List", ll)
+
+	// Red-black tree
+	c = NewRedBlackTree(IntComparator)
+	logContainerSatisfaction(t, "Red-Black Tree", c)
+
+	// AVL tree
+	c = NewAVLTree(IntComparator)
+	logContainerSatisfaction(t, "AVL Tree", c)
+
+	// Treap
+	c = NewTreap(IntComparator, 1)
+	logContainerSatisfaction(t, "Treap", c)
+
+	// Order statistic tree
+	c = NewOrderStatisticTree(IntComparator)
+	logContainerSatisfaction(t, "Order Statistic Tree", c)
 }
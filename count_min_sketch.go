@@ -0,0 +1,214 @@
+package ads
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// CountMinSketch estimates the frequency of keys in a stream using a fixed amount of memory. It
+// keeps depth rows of width counters; each key increments one counter per row, and its estimate
+// is the smallest of them. Estimates never fall below the true count, and exceed it by at most
+// ε times the total count with probability 1-δ.
+type CountMinSketch struct {
+	counts       [][]int
+	width, depth int
+	// conservative sketches only raise the counters of a key up to its new estimate.
+	conservative bool
+	total        int
+}
+
+// NewCountMinSketch returns an empty sketch whose estimates exceed the true counts by at most
+// epsilon times the total count with probability 1-delta, using ⌈e/ε⌉ columns and ⌈ln 1/δ⌉ rows.
+// Conservative sketches raise each counter of a key only as far as needed for its estimate to
+// grow by the added count, which lowers the error but prevents merging sketches.
+func NewCountMinSketch(epsilon, delta float64, conservative bool) (*CountMinSketch, error) {
+	if epsilon <= 0 || epsilon >= 1 {
+		return nil, fmt.Errorf("epsilon must be in (0, 1), got %v", epsilon)
+	}
+	if delta <= 0 || delta >= 1 {
+		return nil, fmt.Errorf("delta must be in (0, 1), got %v", delta)
+	}
+	s := &CountMinSketch{
+		width:        int(math.Ceil(math.E / epsilon)),
+		depth:        int(math.Ceil(math.Log(1 / delta))),
+		conservative: conservative,
+	}
+	s.counts = make([][]int, s.depth)
+	for i := range s.counts {
+		s.counts[i] = make([]int, s.width)
+	}
+	return s, nil
+}
+
+// locations returns the counter of key in each row. Rows use independent hash functions, FNV-1a
+// seeded with the row number followed by the MurmurHash3 finalizer, so keys colliding in one row
+// are unlikely to collide in another.
+func (s *CountMinSketch) locations(key string) []int {
+	cols := make([]int, s.depth)
+	var seed [8]byte
+	for row := range cols {
+		binary.BigEndian.PutUint64(seed[:], uint64(row))
+		h := fnv.New64a()
+		h.Write(seed[:])
+		h.Write([]byte(key))
+		cols[row] = int(fmix64(h.Sum64()) % uint64(s.width))
+	}
+	return cols
+}
+
+// Add counts count more occurrences of key and returns its new estimate. count must be
+// non-negative.
+func (s *CountMinSketch) Add(key string, count int) (int, error) {
+	if count < 0 {
+		return 0, fmt.Errorf("count must be non-negative, got %d", count)
+	}
+	s.total += count
+	cols := s.locations(key)
+	estimate := s.estimate(cols) + count
+	for row, col := range cols {
+		if s.conservative {
+			s.counts[row][col] = maxInts(s.counts[row][col], estimate)
+		} else {
+			s.counts[row][col] += count
+		}
+	}
+	return estimate, nil
+}
+
+// estimate returns the smallest of the given counters, one per row.
+func (s *CountMinSketch) estimate(cols []int) int {
+	estimate := maxInt
+	for row, col := range cols {
+		estimate = minInts(estimate, s.counts[row][col])
+	}
+	return estimate
+}
+
+// Estimate returns an upper bound of the number of occurrences of key.
+func (s *CountMinSketch) Estimate(key string) int {
+	return s.estimate(s.locations(key))
+}
+
+// Total returns the number of occurrences counted across all keys.
+func (s *CountMinSketch) Total() int {
+	return s.total
+}
+
+// Merge adds the counts of another sketch of the same dimensions to this one. Conservative
+// sketches can't be merged since their counters don't add up.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s.conservative || other.conservative {
+		return fmt.Errorf("conservative sketches can't be merged")
+	}
+	if s.width != other.width || s.depth != other.depth {
+		return fmt.Errorf("sketches differ: %dx%d counters, other has %dx%d counters",
+			s.depth, s.width, other.depth, other.width)
+	}
+	for row := range s.counts {
+		for col, c := range other.counts[row] {
+			s.counts[row][col] += c
+		}
+	}
+	s.total += other.total
+	return nil
+}
+
+// HeavyHitters tracks the k most frequent keys of a stream counted by a CountMinSketch. Tracked
+// keys are kept in a min-heap keyed by their estimate, so a new key replaces the least frequent
+// one as soon as its estimate is larger. Collisions in the sketch may raise the estimate of a
+// tracked key after it was added, so the minimum is refreshed before comparing.
+type HeavyHitters struct {
+	sketch *CountMinSketch
+	k      int
+	heap   *DaryHeap
+	// items holds the heap item of each tracked key.
+	items map[string]*HeapItem
+}
+
+// NewHeavyHitters returns a tracker of the k most frequent keys counted by sketch. Keys must be
+// counted through the tracker to be considered.
+func NewHeavyHitters(k int, sketch *CountMinSketch) (*HeavyHitters, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("number of heavy hitters must be positive, got %d", k)
+	}
+	h, _ := NewDaryHeap(2)
+	return &HeavyHitters{sketch: sketch, k: k, heap: h, items: map[string]*HeapItem{}}, nil
+}
+
+// Add counts count more occurrences of key in the sketch and updates the tracked keys. It takes
+// O(log k) time besides updating the sketch and refreshing stale estimates.
+func (h *HeavyHitters) Add(key string, count int) error {
+	estimate, err := h.sketch.Add(key, count)
+	if err != nil {
+		return err
+	}
+	if item, ok := h.items[key]; ok {
+		return h.update(item, estimate)
+	}
+	if h.heap.Size() == h.k {
+		min, err := h.freshMin()
+		if err != nil {
+			return err
+		}
+		if min.Key >= estimate {
+			return nil
+		}
+		if _, err := h.heap.DeleteMin(); err != nil {
+			return err
+		}
+		delete(h.items, min.Value.(string))
+	}
+	h.items[key] = h.heap.Insert(estimate, key)
+	return nil
+}
+
+// update sets the estimate of a tracked key. DaryHeap can't raise keys, so the item is removed
+// and inserted again.
+func (h *HeavyHitters) update(item *HeapItem, estimate int) error {
+	if err := h.heap.DecreaseKey(item, minInt); err != nil {
+		return err
+	}
+	if _, err := h.heap.DeleteMin(); err != nil {
+		return err
+	}
+	key := item.Value.(string)
+	h.items[key] = h.heap.Insert(estimate, key)
+	return nil
+}
+
+// freshMin returns the tracked key with the smallest estimate, updating the stale estimates found
+// on top of the heap first.
+func (h *HeavyHitters) freshMin() (*HeapItem, error) {
+	for {
+		min, err := h.heap.FindMin()
+		if err != nil {
+			return nil, err
+		}
+		estimate := h.sketch.Estimate(min.Value.(string))
+		if estimate == min.Key {
+			return min, nil
+		}
+		if err := h.update(min, estimate); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Top returns the tracked keys with their current estimates, sorted by decreasing estimate and
+// then lexicographically.
+func (h *HeavyHitters) Top() []WeightedKey {
+	top := make([]WeightedKey, 0, len(h.items))
+	for key := range h.items {
+		top = append(top, WeightedKey{Key: key, Weight: h.sketch.Estimate(key)})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Weight != top[j].Weight {
+			return top[i].Weight > top[j].Weight
+		}
+		return top[i].Key < top[j].Key
+	})
+	return top
+}
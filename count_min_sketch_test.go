@@ -0,0 +1,187 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// zipfStream returns n keys drawn from a Zipf distribution over 1000 keys, so a few keys are very
+// frequent, and the exact count of each key.
+func zipfStream(seed int64, n int) ([]string, map[string]int) {
+	z := rand.NewZipf(rand.New(rand.NewSource(seed)), 1.2, 1, 999)
+	stream, counts := make([]string, n), map[string]int{}
+	for i := range stream {
+		stream[i] = fmt.Sprintf("key-%d", z.Uint64())
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+func TestCountMinSketch(t *testing.T) {
+	const epsilon, delta, n = 0.001, 0.01, 100000
+	stream, counts := zipfStream(1, n)
+	plain, err := NewCountMinSketch(epsilon, delta, false)
+	if err != nil {
+		t.Fatalf("NewCountMinSketch() returned unexpected error; %v", err)
+	}
+	conservative, _ := NewCountMinSketch(epsilon, delta, true)
+	for _, key := range stream {
+		plain.Add(key, 1)
+		conservative.Add(key, 1)
+	}
+	if plain.Total() != n || conservative.Total() != n {
+		t.Errorf("Total(): %d and %d, want %d", plain.Total(), conservative.Total(), n)
+	}
+	exceeded := 0
+	for key, count := range counts {
+		p, c := plain.Estimate(key), conservative.Estimate(key)
+		if c < count || p < c {
+			t.Fatalf("%q appears %d times, estimated %d and %d conservatively", key, count, p, c)
+		}
+		if p > count+int(epsilon*n) {
+			exceeded++
+		}
+	}
+	if rate := float64(exceeded) / float64(len(counts)); rate > delta {
+		t.Errorf("%v of the estimates exceed the error bound", rate)
+	}
+	if got := plain.Estimate("never-seen"); got > int(epsilon*n) {
+		t.Errorf("Estimate(\"never-seen\"): %d, want at most %d", got, int(epsilon*n))
+	}
+}
+
+func TestCountMinSketch_Errors(t *testing.T) {
+	for _, test := range [][2]float64{{0, 0.1}, {1, 0.1}, {0.1, 0}, {0.1, 1}} {
+		if _, err := NewCountMinSketch(test[0], test[1], false); err == nil {
+			t.Errorf("NewCountMinSketch(%v, %v) returned nil error, want error", test[0], test[1])
+		}
+	}
+	s, _ := NewCountMinSketch(0.1, 0.1, false)
+	if _, err := s.Add("a", -1); err == nil {
+		t.Error("Add() returned nil error for a negative count, want error")
+	}
+	conservative, _ := NewCountMinSketch(0.1, 0.1, true)
+	if err := s.Merge(conservative); err == nil {
+		t.Error("Merge() returned nil error for a conservative sketch, want error")
+	}
+	other, _ := NewCountMinSketch(0.01, 0.1, false)
+	if err := s.Merge(other); err == nil {
+		t.Error("Merge() returned nil error for sketches of different widths, want error")
+	}
+	if _, err := NewHeavyHitters(0, s); err == nil {
+		t.Error("NewHeavyHitters(0) returned nil error, want error")
+	}
+}
+
+func TestCountMinSketch_IndependentRows(t *testing.T) {
+	s, _ := NewCountMinSketch(0.05, 0.01, false)
+	// Keys colliding in the first two rows should collide in the third one about once per width,
+	// rather than always as with double hashing.
+	byCols := map[[2]int][]int{}
+	for i := 0; i < 2000; i++ {
+		cols := s.locations(fmt.Sprint(i))
+		byCols[[2]int{cols[0], cols[1]}] = append(byCols[[2]int{cols[0], cols[1]}], cols[2])
+	}
+	pairs, all := 0, 0
+	for _, third := range byCols {
+		for i := range third {
+			for j := i + 1; j < len(third); j++ {
+				pairs++
+				if third[i] == third[j] {
+					all++
+				}
+			}
+		}
+	}
+	if max := 2 * pairs / s.width; pairs == 0 || all > max {
+		t.Errorf("%d of %d pairs colliding in the first two rows collide in the third, want at most %d",
+			all, pairs, max)
+	}
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	a, _ := NewCountMinSketch(0.01, 0.01, false)
+	b, _ := NewCountMinSketch(0.01, 0.01, false)
+	both, _ := NewCountMinSketch(0.01, 0.01, false)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key-%d", i%37)
+		if i%3 == 0 {
+			a.Add(key, i)
+		} else {
+			b.Add(key, i)
+		}
+		both.Add(key, i)
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() returned unexpected error; %v", err)
+	}
+	if a.Total() != both.Total() {
+		t.Errorf("Total(): %d, want %d", a.Total(), both.Total())
+	}
+	for i := 0; i < 37; i++ {
+		if key := fmt.Sprintf("key-%d", i); a.Estimate(key) != both.Estimate(key) {
+			t.Fatalf("Estimate(%q): %d, want %d", key, a.Estimate(key), both.Estimate(key))
+		}
+	}
+}
+
+func TestHeavyHitters(t *testing.T) {
+	const k = 10
+	stream, counts := zipfStream(2, 200000)
+	s, _ := NewCountMinSketch(0.0005, 0.001, true)
+	h, _ := NewHeavyHitters(k, s)
+	for _, key := range stream {
+		if err := h.Add(key, 1); err != nil {
+			t.Fatalf("Add() returned unexpected error; %v", err)
+		}
+	}
+	// The Zipf distribution separates the most frequent keys enough for the estimates to keep
+	// their order.
+	var want []string
+	for i := 0; i < k; i++ {
+		want = append(want, fmt.Sprintf("key-%d", i))
+	}
+	top := h.Top()
+	var got []string
+	for i, w := range top {
+		got = append(got, w.Key)
+		if w.Weight < counts[w.Key] || (i > 0 && w.Weight > top[i-1].Weight) {
+			t.Errorf("Top()[%d]: %v, true count %d", i, w, counts[w.Key])
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Top() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHeavyHitters_StaleEstimates(t *testing.T) {
+	// A single row of 4 counters, so collisions are easy to find.
+	s, _ := NewCountMinSketch(0.9, 0.5, false)
+	x := "x"
+	var y, z string
+	for i := 0; y == "" || z == ""; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		if s.locations(key)[0] == s.locations(x)[0] {
+			y = key
+		} else {
+			z = key
+		}
+	}
+	h, _ := NewHeavyHitters(2, s)
+	for _, add := range []struct {
+		key   string
+		count int
+	}{{x, 1}, {z, 3}, {y, 5}} {
+		if err := h.Add(add.key, add.count); err != nil {
+			t.Fatalf("Add(%q, %d) returned unexpected error; %v", add.key, add.count, err)
+		}
+	}
+	// Adding y raised the estimate of x to 6, so z is the least frequent tracked key.
+	want := []WeightedKey{{y, 6}, {x, 6}}
+	if diff := cmp.Diff(want, h.Top()); diff != "" {
+		t.Errorf("Top() mismatch (-want +got):\n%s", diff)
+	}
+}
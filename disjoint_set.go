@@ -0,0 +1,328 @@
+package ads
+
+import "fmt"
+
+// DisjointSet (union-find) keeps track of a partition of the elements 0, ..., n-1 into disjoint
+// sets. It uses union by rank and path compression, so any sequence of m operations takes
+// O(m α(n)) time, where α is the inverse Ackermann function.
+type DisjointSet struct {
+	parent []int
+	// rank is an upper bound of the height of the tree rooted at each element.
+	rank []int
+	// size is the number of elements of the set rooted at each element.
+	size  []int
+	count int
+}
+
+// NewDisjointSet returns a disjoint set where each of the elements 0, ..., n-1 is in its own set.
+func NewDisjointSet(n int) *DisjointSet {
+	ds := &DisjointSet{}
+	for i := 0; i < n; i++ {
+		ds.Add()
+	}
+	return ds
+}
+
+func disjointSetIndexError(n, x int) error {
+	return fmt.Errorf("invalid element %d, disjoint set has %d elements", x, n)
+}
+
+// Add inserts a new element in its own set and returns it.
+func (ds *DisjointSet) Add() int {
+	x := len(ds.parent)
+	ds.parent = append(ds.parent, x)
+	ds.rank = append(ds.rank, 0)
+	ds.size = append(ds.size, 1)
+	ds.count++
+	return x
+}
+
+func (ds *DisjointSet) validate(xs ...int) error {
+	for _, x := range xs {
+		if x < 0 || x >= len(ds.parent) {
+			return disjointSetIndexError(len(ds.parent), x)
+		}
+	}
+	return nil
+}
+
+// root returns the representative of x without changing the forest.
+func (ds *DisjointSet) root(x int) int {
+	for ds.parent[x] != x {
+		x = ds.parent[x]
+	}
+	return x
+}
+
+// find returns the representative of x, pointing every element on the way directly to it.
+func (ds *DisjointSet) find(x int) int {
+	r := ds.root(x)
+	for ds.parent[x] != r {
+		ds.parent[x], x = r, ds.parent[x]
+	}
+	return r
+}
+
+// link merges the sets rooted at the distinct roots rx and ry, hanging the one with the smallest
+// rank below the other. It returns the root that was hung and whether the rank of the new root
+// increased.
+func (ds *DisjointSet) link(rx, ry int) (int, bool) {
+	if ds.rank[rx] < ds.rank[ry] {
+		rx, ry = ry, rx
+	}
+	ds.parent[ry] = rx
+	ds.size[rx] += ds.size[ry]
+	ds.count--
+	if ds.rank[rx] == ds.rank[ry] {
+		ds.rank[rx]++
+		return ry, true
+	}
+	return ry, false
+}
+
+// Find returns the representative of the set containing x.
+func (ds *DisjointSet) Find(x int) (int, error) {
+	if err := ds.validate(x); err != nil {
+		return 0, err
+	}
+	return ds.find(x), nil
+}
+
+// Union merges the sets containing x and y. Returns whether they were different sets.
+func (ds *DisjointSet) Union(x, y int) (bool, error) {
+	if err := ds.validate(x, y); err != nil {
+		return false, err
+	}
+	rx, ry := ds.find(x), ds.find(y)
+	if rx == ry {
+		return false, nil
+	}
+	ds.link(rx, ry)
+	return true, nil
+}
+
+// Connected returns whether x and y are in the same set.
+func (ds *DisjointSet) Connected(x, y int) (bool, error) {
+	if err := ds.validate(x, y); err != nil {
+		return false, err
+	}
+	return ds.find(x) == ds.find(y), nil
+}
+
+// SetSize returns the number of elements in the set containing x.
+func (ds *DisjointSet) SetSize(x int) (int, error) {
+	if err := ds.validate(x); err != nil {
+		return 0, err
+	}
+	return ds.size[ds.find(x)], nil
+}
+
+// Count returns the number of disjoint sets.
+func (ds *DisjointSet) Count() int {
+	return ds.count
+}
+
+// Size returns the number of elements in the disjoint set.
+func (ds *DisjointSet) Size() int {
+	return len(ds.parent)
+}
+
+// HashDisjointSet is a DisjointSet over arbitrary values. Values are mapped to dense elements
+// through a HashTable indexed by a string key computed for each value.
+type HashDisjointSet struct {
+	ds     DisjointSet
+	ids    *HashTable
+	values []interface{}
+	key    func(v interface{}) string
+}
+
+// NewHashDisjointSet returns an empty disjoint set where values are told apart by the given key
+// function. If key is nil, values are identified by their type and Go-syntax representation
+// (fmt's %T and %#v verbs), which suits numbers, strings, pointers and structs of them.
+func NewHashDisjointSet(key func(v interface{}) string) *HashDisjointSet {
+	if key == nil {
+		key = func(v interface{}) string { return fmt.Sprintf("%[1]T:%#[1]v", v) }
+	}
+	return &HashDisjointSet{ids: NewHashTable(), key: key}
+}
+
+// id returns the element of v.
+func (h *HashDisjointSet) id(v interface{}) (int, error) {
+	id, ok := h.ids.Get(h.key(v))
+	if !ok {
+		return 0, fmt.Errorf("%v is not in the disjoint set", v)
+	}
+	return id.(int), nil
+}
+
+// Add inserts v in its own set. Values already stored are ignored.
+func (h *HashDisjointSet) Add(v interface{}) {
+	k := h.key(v)
+	if _, ok := h.ids.Get(k); ok {
+		return
+	}
+	h.ids.Set(k, h.ds.Add())
+	h.values = append(h.values, v)
+}
+
+// Contains returns whether v is stored in the disjoint set.
+func (h *HashDisjointSet) Contains(v interface{}) bool {
+	_, ok := h.ids.Get(h.key(v))
+	return ok
+}
+
+// Find returns the representative value of the set containing v.
+func (h *HashDisjointSet) Find(v interface{}) (interface{}, error) {
+	x, err := h.id(v)
+	if err != nil {
+		return nil, err
+	}
+	return h.values[h.ds.find(x)], nil
+}
+
+// Union merges the sets containing u and v. Returns whether they were different sets.
+func (h *HashDisjointSet) Union(u, v interface{}) (bool, error) {
+	x, err := h.id(u)
+	if err != nil {
+		return false, err
+	}
+	y, err := h.id(v)
+	if err != nil {
+		return false, err
+	}
+	return h.ds.Union(x, y)
+}
+
+// Connected returns whether u and v are in the same set.
+func (h *HashDisjointSet) Connected(u, v interface{}) (bool, error) {
+	x, err := h.id(u)
+	if err != nil {
+		return false, err
+	}
+	y, err := h.id(v)
+	if err != nil {
+		return false, err
+	}
+	return h.ds.Connected(x, y)
+}
+
+// SetSize returns the number of values in the set containing v.
+func (h *HashDisjointSet) SetSize(v interface{}) (int, error) {
+	x, err := h.id(v)
+	if err != nil {
+		return 0, err
+	}
+	return h.ds.SetSize(x)
+}
+
+// Count returns the number of disjoint sets.
+func (h *HashDisjointSet) Count() int {
+	return h.ds.Count()
+}
+
+// Size returns the number of values in the disjoint set.
+func (h *HashDisjointSet) Size() int {
+	return h.ds.Size()
+}
+
+// disjointSetChange records a link so it can be undone.
+type disjointSetChange struct {
+	// child is the root hung below the other one.
+	child       int
+	rankChanged bool
+}
+
+// RollbackDisjointSet is a DisjointSet whose unions can be undone in reverse order, as needed by
+// offline algorithms such as dynamic connectivity over a segment tree of time. It doesn't use path
+// compression (which can't be undone cheaply), so operations take O(log n) time.
+type RollbackDisjointSet struct {
+	ds      DisjointSet
+	history []disjointSetChange
+}
+
+// NewRollbackDisjointSet returns a disjoint set where each of the elements 0, ..., n-1 is in its
+// own set.
+func NewRollbackDisjointSet(n int) *RollbackDisjointSet {
+	return &RollbackDisjointSet{ds: *NewDisjointSet(n)}
+}
+
+// Find returns the representative of the set containing x.
+func (r *RollbackDisjointSet) Find(x int) (int, error) {
+	if err := r.ds.validate(x); err != nil {
+		return 0, err
+	}
+	return r.ds.root(x), nil
+}
+
+// Union merges the sets containing x and y. Returns whether they were different sets. Only
+// merges are recorded in the history.
+func (r *RollbackDisjointSet) Union(x, y int) (bool, error) {
+	if err := r.ds.validate(x, y); err != nil {
+		return false, err
+	}
+	rx, ry := r.ds.root(x), r.ds.root(y)
+	if rx == ry {
+		return false, nil
+	}
+	child, rankChanged := r.ds.link(rx, ry)
+	r.history = append(r.history, disjointSetChange{child: child, rankChanged: rankChanged})
+	return true, nil
+}
+
+// Connected returns whether x and y are in the same set.
+func (r *RollbackDisjointSet) Connected(x, y int) (bool, error) {
+	if err := r.ds.validate(x, y); err != nil {
+		return false, err
+	}
+	return r.ds.root(x) == r.ds.root(y), nil
+}
+
+// SetSize returns the number of elements in the set containing x.
+func (r *RollbackDisjointSet) SetSize(x int) (int, error) {
+	if err := r.ds.validate(x); err != nil {
+		return 0, err
+	}
+	return r.ds.size[r.ds.root(x)], nil
+}
+
+// Count returns the number of disjoint sets.
+func (r *RollbackDisjointSet) Count() int {
+	return r.ds.Count()
+}
+
+// Size returns the number of elements in the disjoint set.
+func (r *RollbackDisjointSet) Size() int {
+	return r.ds.Size()
+}
+
+// Snapshot returns the current version of the disjoint set, to be given to Rollback.
+func (r *RollbackDisjointSet) Snapshot() int {
+	return len(r.history)
+}
+
+// Rollback undoes every merge made after the given snapshot was taken.
+func (r *RollbackDisjointSet) Rollback(snapshot int) error {
+	if snapshot < 0 || snapshot > len(r.history) {
+		return fmt.Errorf("invalid snapshot %d, current version is %d", snapshot, len(r.history))
+	}
+	for len(r.history) > snapshot {
+		c := r.history[len(r.history)-1]
+		r.history = r.history[:len(r.history)-1]
+		p := r.ds.parent[c.child]
+		r.ds.parent[c.child] = c.child
+		r.ds.size[p] -= r.ds.size[c.child]
+		if c.rankChanged {
+			r.ds.rank[p]--
+		}
+		r.ds.count++
+	}
+	return nil
+}
+
+// Undo reverts the last merge.
+func (r *RollbackDisjointSet) Undo() error {
+	if len(r.history) == 0 {
+		return fmt.Errorf("there isn't a merge to undo")
+	}
+	return r.Rollback(len(r.history) - 1)
+}
@@ -0,0 +1,242 @@
+package ads
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// naivePartition labels every element with the id of its set, merging sets in O(n).
+type naivePartition []int
+
+func newNaivePartition(n int) naivePartition {
+	p := make(naivePartition, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+func (p naivePartition) union(x, y int) bool {
+	from, to := p[y], p[x]
+	if from == to {
+		return false
+	}
+	for i := range p {
+		if p[i] == from {
+			p[i] = to
+		}
+	}
+	return true
+}
+
+func (p naivePartition) setSize(x int) int {
+	size := 0
+	for i := range p {
+		if p[i] == p[x] {
+			size++
+		}
+	}
+	return size
+}
+
+func (p naivePartition) count() int {
+	sets := map[int]bool{}
+	for _, s := range p {
+		sets[s] = true
+	}
+	return len(sets)
+}
+
+// unionFind is implemented by both DisjointSet and RollbackDisjointSet.
+type unionFind interface {
+	Find(x int) (int, error)
+	Union(x, y int) (bool, error)
+	Connected(x, y int) (bool, error)
+	SetSize(x int) (int, error)
+	Count() int
+	Size() int
+}
+
+// checkPartition verifies every query of ds against the naive partition.
+func checkPartition(t *testing.T, ds unionFind, p naivePartition) {
+	t.Helper()
+	if ds.Count() != p.count() {
+		t.Fatalf("Count(): %d, want %d", ds.Count(), p.count())
+	}
+	for x := range p {
+		size, err := ds.SetSize(x)
+		if err != nil || size != p.setSize(x) {
+			t.Fatalf("SetSize(%d): %d, %v, want %d, nil", x, size, err, p.setSize(x))
+		}
+		rx, _ := ds.Find(x)
+		for y := range p {
+			ry, _ := ds.Find(y)
+			connected, err := ds.Connected(x, y)
+			if want := p[x] == p[y]; err != nil || connected != want || (rx == ry) != want {
+				t.Fatalf("Connected(%d, %d): %v, %v, want %v, nil", x, y, connected, err, want)
+			}
+		}
+	}
+}
+
+func TestDisjointSet_ThroughOps(t *testing.T) {
+	const n = 50
+	r := rand.New(rand.NewSource(1))
+	ds := NewDisjointSet(n)
+	p := newNaivePartition(n)
+	for i := 0; i < 3*n; i++ {
+		x, y := r.Intn(n), r.Intn(n)
+		merged, err := ds.Union(x, y)
+		if want := p.union(x, y); err != nil || merged != want {
+			t.Fatalf("Union(%d, %d): %v, %v, want %v, nil", x, y, merged, err, want)
+		}
+		checkPartition(t, ds, p)
+	}
+	if x := ds.Add(); x != n || ds.Size() != n+1 || ds.Count() != p.count()+1 {
+		t.Errorf("Add(): %d, Size(): %d, Count(): %d after adding an element", x, ds.Size(), ds.Count())
+	}
+}
+
+func TestDisjointSet_Errors(t *testing.T) {
+	for name, ds := range map[string]unionFind{
+		"disjoint set":          NewDisjointSet(3),
+		"rollback disjoint set": NewRollbackDisjointSet(3),
+	} {
+		for _, x := range []int{-1, 3} {
+			if _, err := ds.Find(x); err == nil {
+				t.Errorf("%s: Find(%d) returned nil error, want error", name, x)
+			}
+			if _, err := ds.Union(0, x); err == nil {
+				t.Errorf("%s: Union(0, %d) returned nil error, want error", name, x)
+			}
+			if _, err := ds.Connected(x, 0); err == nil {
+				t.Errorf("%s: Connected(%d, 0) returned nil error, want error", name, x)
+			}
+			if _, err := ds.SetSize(x); err == nil {
+				t.Errorf("%s: SetSize(%d) returned nil error, want error", name, x)
+			}
+		}
+	}
+}
+
+func TestRollbackDisjointSet_ThroughOps(t *testing.T) {
+	const n = 40
+	r := rand.New(rand.NewSource(1))
+	ds := NewRollbackDisjointSet(n)
+	var snapshots []int
+	partitions := []naivePartition{newNaivePartition(n)}
+	for i := 0; i < 400; i++ {
+		p := partitions[len(partitions)-1]
+		switch op := r.Intn(10); {
+		case op < 2:
+			snapshots = append(snapshots, ds.Snapshot())
+			partitions = append(partitions, append(naivePartition{}, p...))
+		case op < 3 && len(snapshots) > 0:
+			if err := ds.Rollback(snapshots[len(snapshots)-1]); err != nil {
+				t.Fatalf("Rollback() returned unexpected error; %v", err)
+			}
+			snapshots = snapshots[:len(snapshots)-1]
+			partitions = partitions[:len(partitions)-1]
+		default:
+			x, y := r.Intn(n), r.Intn(n)
+			merged, err := ds.Union(x, y)
+			if want := p.union(x, y); err != nil || merged != want {
+				t.Fatalf("Union(%d, %d): %v, %v, want %v, nil", x, y, merged, err, want)
+			}
+		}
+		checkPartition(t, ds, partitions[len(partitions)-1])
+	}
+}
+
+func TestRollbackDisjointSet_Undo(t *testing.T) {
+	ds := NewRollbackDisjointSet(4)
+	if err := ds.Undo(); err == nil {
+		t.Error("Undo() without merges returned nil error, want error")
+	}
+	ds.Union(0, 1)
+	ds.Union(0, 1)
+	ds.Union(2, 3)
+	if err := ds.Undo(); err != nil {
+		t.Fatalf("Undo() returned unexpected error; %v", err)
+	}
+	checkPartition(t, ds, naivePartition{0, 0, 2, 3})
+	for _, snapshot := range []int{-1, 2} {
+		if err := ds.Rollback(snapshot); err == nil {
+			t.Errorf("Rollback(%d) returned nil error, want error", snapshot)
+		}
+	}
+	if err := ds.Rollback(0); err != nil {
+		t.Fatalf("Rollback(0) returned unexpected error; %v", err)
+	}
+	checkPartition(t, ds, newNaivePartition(4))
+}
+
+func TestHashDisjointSet(t *testing.T) {
+	type point struct{ x, y int }
+	h := NewHashDisjointSet(nil)
+	values := []interface{}{"a", "b", "日本", 1, int64(1), "1", point{1, 2}, point{2, 1}}
+	for _, v := range values {
+		h.Add(v)
+		h.Add(v)
+	}
+	if h.Size() != len(values) || h.Count() != len(values) {
+		t.Fatalf("Size(): %d, Count(): %d, want %d", h.Size(), h.Count(), len(values))
+	}
+	for _, pair := range [][2]interface{}{{"a", 1}, {1, point{1, 2}}, {"b", "日本"}} {
+		if merged, err := h.Union(pair[0], pair[1]); err != nil || !merged {
+			t.Fatalf("Union(%v, %v): %v, %v, want true, nil", pair[0], pair[1], merged, err)
+		}
+	}
+	tests := []struct {
+		u, v interface{}
+		want bool
+	}{
+		{u: "a", v: point{1, 2}, want: true},
+		{u: "b", v: "日本", want: true},
+		{u: 1, v: int64(1), want: false},
+		{u: 1, v: "1", want: false},
+		{u: point{1, 2}, v: point{2, 1}, want: false},
+	}
+	for _, test := range tests {
+		if got, err := h.Connected(test.u, test.v); err != nil || got != test.want {
+			t.Errorf("Connected(%v, %v): %v, %v, want %v, nil", test.u, test.v, got, err, test.want)
+		}
+	}
+	if size, err := h.SetSize(point{1, 2}); err != nil || size != 3 {
+		t.Errorf("SetSize(point{1, 2}): %d, %v, want 3, nil", size, err)
+	}
+	ra, _ := h.Find("a")
+	rp, _ := h.Find(point{1, 2})
+	if diff := cmp.Diff(ra, rp, cmp.AllowUnexported(point{})); diff != "" || !h.Contains(ra) {
+		t.Errorf("Find() returned different representatives for the same set (-a +point):\n%s", diff)
+	}
+	if h.Count() != len(values)-3 {
+		t.Errorf("Count(): %d, want %d", h.Count(), len(values)-3)
+	}
+	if _, err := h.Find("missing"); err == nil {
+		t.Error(`Find("missing") returned nil error, want error`)
+	}
+	if _, err := h.Union("a", "missing"); err == nil {
+		t.Error(`Union("a", "missing") returned nil error, want error`)
+	}
+	if _, err := h.Connected("missing", "a"); err == nil {
+		t.Error(`Connected("missing", "a") returned nil error, want error`)
+	}
+	if _, err := h.SetSize("missing"); err == nil {
+		t.Error(`SetSize("missing") returned nil error, want error`)
+	}
+}
+
+func TestHashDisjointSet_KeyFunc(t *testing.T) {
+	// Case-insensitive sets.
+	h := NewHashDisjointSet(func(v interface{}) string { return strings.ToLower(v.(string)) })
+	h.Add("Go")
+	h.Add("GO")
+	h.Add("Rust")
+	if h.Size() != 2 || !h.Contains("go") {
+		t.Errorf("Size(): %d, Contains(\"go\"): %v, want 2, true", h.Size(), h.Contains("go"))
+	}
+}
@@ -14,27 +14,371 @@ func NewFenwickTree(size int) *FenwickTree {
 	return &FenwickTree{n: size, bit: make([]int, size+1)}
 }
 
+// NewFenwickTreeFrom returns a Fenwick Tree holding the given values, values[0] being stored at
+// index 1. The tree is built in linear time.
+func NewFenwickTreeFrom(values []int) *FenwickTree {
+	t := &FenwickTree{n: len(values), bit: make([]int, len(values)+1)}
+	copy(t.bit[1:], values)
+	// Push each node's partial sum up to its parent once the node is complete.
+	for i := 1; i <= t.n; i++ {
+		if j := i + (i & (-i)); j <= t.n {
+			t.bit[j] += t.bit[i]
+		}
+	}
+	return t
+}
+
+// fenwickQueryError is returned when a prefix query exceeds the tree size.
+func fenwickQueryError(n, i int) error {
+	return fmt.Errorf("tree size is %d, got query for %d elements", n, i)
+}
+
+// fenwickIndexError is returned when an element index is out of range.
+func fenwickIndexError(i int) error {
+	return fmt.Errorf("invalid index %d", i)
+}
+
+// fenwickRangeError is returned when a range is empty or exceeds the tree size.
+func fenwickRangeError(n, l, r int) error {
+	return fmt.Errorf("tree size is %d, got invalid range [%d, %d]", n, l, r)
+}
+
 // Get returns the prefix sum of the first i elements.
 func (t *FenwickTree) Get(i int) (int, error) {
 	if i <= 0 || i > t.n {
-		return 0, fmt.Errorf("tree size is %d, got query for %d elements", t.n, i)
+		return 0, fenwickQueryError(t.n, i)
 	}
+	return t.prefix(i), nil
+}
+
+// prefix returns the sum of the first i elements without validating i.
+func (t *FenwickTree) prefix(i int) int {
 	s := 0
 	for i > 0 {
 		s += t.bit[i]
 		i -= i & (-i)
 	}
-	return s, nil
+	return s
 }
 
 // Add value at the given index.
 func (t *FenwickTree) Add(i, value int) error {
 	if i <= 0 || i > t.n {
-		return fmt.Errorf("invalid index %d", i)
+		return fenwickIndexError(i)
 	}
+	t.add(i, value)
+	return nil
+}
+
+// add value at the given index without validating i.
+func (t *FenwickTree) add(i, value int) {
 	for i <= t.n {
 		t.bit[i] += value
 		i += i & (-i)
 	}
+}
+
+// RangeSum returns the sum of the elements in the closed range [l, r].
+func (t *FenwickTree) RangeSum(l, r int) (int, error) {
+	if l <= 0 || r > t.n || l > r {
+		return 0, fenwickRangeError(t.n, l, r)
+	}
+	return t.prefix(r) - t.prefix(l-1), nil
+}
+
+// PointGet returns the value stored at the given index.
+func (t *FenwickTree) PointGet(i int) (int, error) {
+	if i <= 0 || i > t.n {
+		return 0, fenwickIndexError(i)
+	}
+	// Walk down from i and i-1 until both reach their common ancestor, so only the nodes covering
+	// the i-th element alone are visited.
+	v := t.bit[i]
+	for j, lca := i-1, i-(i&(-i)); j != lca; j -= j & (-j) {
+		v -= t.bit[j]
+	}
+	return v, nil
+}
+
+// Set the value stored at the given index.
+func (t *FenwickTree) Set(i, value int) error {
+	old, err := t.PointGet(i)
+	if err != nil {
+		return err
+	}
+	t.add(i, value-old)
+	return nil
+}
+
+// LowerBound returns the smallest index whose prefix sum is greater than or equal to sum. It uses
+// binary lifting, so all the stored values must be non-negative.
+func (t *FenwickTree) LowerBound(sum int) (int, error) {
+	if t.n == 0 || t.prefix(t.n) < sum {
+		return 0, fmt.Errorf("no prefix sum reaches %d", sum)
+	}
+	if sum <= 0 {
+		return 1, nil
+	}
+	step := 1
+	for step<<1 <= t.n {
+		step <<= 1
+	}
+	pos := 0
+	for ; step > 0; step >>= 1 {
+		if pos+step <= t.n && t.bit[pos+step] < sum {
+			pos += step
+			sum -= t.bit[pos]
+		}
+	}
+	return pos + 1, nil
+}
+
+// Size returns the number of elements in the tree.
+func (t *FenwickTree) Size() int {
+	return t.n
+}
+
+// Append a new element at index Size()+1, growing the tree.
+func (t *FenwickTree) Append(value int) {
+	t.n++
+	// The new node covers the elements in (n - lowbit(n), n], all but the last one already stored.
+	i := t.n
+	t.bit = append(t.bit, value+t.prefix(i-1)-t.prefix(i-(i&(-i))))
+}
+
+// Values returns the elements stored in the tree, the i-th element being at position i-1. The
+// original values are recovered in linear time.
+func (t *FenwickTree) Values() []int {
+	values := make([]int, t.n+1)
+	copy(values, t.bit)
+	// Undo NewFenwickTreeFrom, parents are processed before their children.
+	for i := t.n; i >= 1; i-- {
+		if j := i + (i & (-i)); j <= t.n {
+			values[j] -= values[i]
+		}
+	}
+	return values[1:]
+}
+
+// ZeroIndexed returns a zero-indexed view of the tree. Both the view and the tree share the same
+// data, so updates on one are visible from the other.
+func (t *FenwickTree) ZeroIndexed() *ZeroIndexedFenwickTree {
+	return &ZeroIndexedFenwickTree{t: t}
+}
+
+// ZeroIndexedFenwickTree is a facade over FenwickTree where elements are indexed from 0 to
+// Size()-1. Validation errors are the same as FenwickTree's: invalid indexes and ranges are
+// reported zero-based, and invalid prefix queries by their number of elements.
+type ZeroIndexedFenwickTree struct {
+	t *FenwickTree
+}
+
+// NewZeroIndexedFenwickTree returns a zero-initialized, zero-indexed Fenwick Tree.
+func NewZeroIndexedFenwickTree(size int) *ZeroIndexedFenwickTree {
+	return NewFenwickTree(size).ZeroIndexed()
+}
+
+// Get returns the sum of the elements in [0, i].
+func (z *ZeroIndexedFenwickTree) Get(i int) (int, error) {
+	if i < 0 || i >= z.t.n {
+		return 0, fenwickQueryError(z.t.n, i+1)
+	}
+	return z.t.prefix(i + 1), nil
+}
+
+// Add value at the given index.
+func (z *ZeroIndexedFenwickTree) Add(i, value int) error {
+	if i < 0 || i >= z.t.n {
+		return fenwickIndexError(i)
+	}
+	z.t.add(i+1, value)
+	return nil
+}
+
+// RangeSum returns the sum of the elements in the closed range [l, r].
+func (z *ZeroIndexedFenwickTree) RangeSum(l, r int) (int, error) {
+	if l < 0 || r >= z.t.n || l > r {
+		return 0, fenwickRangeError(z.t.n, l, r)
+	}
+	return z.t.prefix(r+1) - z.t.prefix(l), nil
+}
+
+// PointGet returns the value stored at the given index.
+func (z *ZeroIndexedFenwickTree) PointGet(i int) (int, error) {
+	if i < 0 || i >= z.t.n {
+		return 0, fenwickIndexError(i)
+	}
+	return z.t.PointGet(i + 1)
+}
+
+// Set the value stored at the given index.
+func (z *ZeroIndexedFenwickTree) Set(i, value int) error {
+	if i < 0 || i >= z.t.n {
+		return fenwickIndexError(i)
+	}
+	return z.t.Set(i+1, value)
+}
+
+// LowerBound returns the smallest index whose prefix sum (as returned by Get) is greater than or
+// equal to sum. All the stored values must be non-negative.
+func (z *ZeroIndexedFenwickTree) LowerBound(sum int) (int, error) {
+	i, err := z.t.LowerBound(sum)
+	if err != nil {
+		return 0, err
+	}
+	return i - 1, nil
+}
+
+// Size returns the number of elements in the tree.
+func (z *ZeroIndexedFenwickTree) Size() int {
+	return z.t.n
+}
+
+// Append a new element at index Size(), growing the tree.
+func (z *ZeroIndexedFenwickTree) Append(value int) {
+	z.t.Append(value)
+}
+
+// Values returns the elements stored in the tree.
+func (z *ZeroIndexedFenwickTree) Values() []int {
+	return z.t.Values()
+}
+
+// RangeFenwickTree supports adding a value to a range of elements and querying range sums, both
+// in logarithmic time. It is built from two Fenwick Trees B1 and B2 so that the prefix sum of the
+// first i elements is i·B1(i) - B2(i).
+// Important: this implementation is one-indexed.
+type RangeFenwickTree struct {
+	b1, b2 *FenwickTree
+	n      int
+}
+
+// NewRangeFenwickTree returns a zero-initialized range-update Fenwick Tree.
+func NewRangeFenwickTree(size int) *RangeFenwickTree {
+	return &RangeFenwickTree{n: size, b1: NewFenwickTree(size), b2: NewFenwickTree(size)}
+}
+
+// AddRange adds value to every element in the closed range [l, r].
+func (t *RangeFenwickTree) AddRange(l, r, value int) error {
+	if l <= 0 || r > t.n || l > r {
+		return fenwickRangeError(t.n, l, r)
+	}
+	t.b1.add(l, value)
+	t.b1.add(r+1, -value)
+	t.b2.add(l, value*(l-1))
+	t.b2.add(r+1, -value*r)
+	return nil
+}
+
+// Add value at the given index.
+func (t *RangeFenwickTree) Add(i, value int) error {
+	if i <= 0 || i > t.n {
+		return fenwickIndexError(i)
+	}
+	return t.AddRange(i, i, value)
+}
+
+// prefix returns the sum of the first i elements without validating i.
+func (t *RangeFenwickTree) prefix(i int) int {
+	return t.b1.prefix(i)*i - t.b2.prefix(i)
+}
+
+// Get returns the prefix sum of the first i elements.
+func (t *RangeFenwickTree) Get(i int) (int, error) {
+	if i <= 0 || i > t.n {
+		return 0, fenwickQueryError(t.n, i)
+	}
+	return t.prefix(i), nil
+}
+
+// RangeSum returns the sum of the elements in the closed range [l, r].
+func (t *RangeFenwickTree) RangeSum(l, r int) (int, error) {
+	if l <= 0 || r > t.n || l > r {
+		return 0, fenwickRangeError(t.n, l, r)
+	}
+	return t.prefix(r) - t.prefix(l-1), nil
+}
+
+// PointGet returns the value stored at the given index.
+func (t *RangeFenwickTree) PointGet(i int) (int, error) {
+	return t.RangeSum(i, i)
+}
+
+// GroupFenwickTree is a Fenwick Tree whose elements belong to an arbitrary abelian group, so
+// prefix "sums" are computed with the group operation instead of integer addition.
+// Important: this implementation is one-indexed.
+type GroupFenwickTree struct {
+	g   Group
+	bit []interface{}
+	n   int
+}
+
+// NewGroupFenwickTree returns a Fenwick Tree over the given group with every element set to the
+// group identity.
+func NewGroupFenwickTree(g Group, size int) *GroupFenwickTree {
+	t := &GroupFenwickTree{g: g, n: size, bit: make([]interface{}, size+1)}
+	for i := range t.bit {
+		t.bit[i] = g.Identity()
+	}
+	return t
+}
+
+// prefix returns the combination of the first i elements without validating i.
+func (t *GroupFenwickTree) prefix(i int) interface{} {
+	s := t.g.Identity()
+	for i > 0 {
+		s = t.g.Op(s, t.bit[i])
+		i -= i & (-i)
+	}
+	return s
+}
+
+// Get returns the combination of the first i elements.
+func (t *GroupFenwickTree) Get(i int) (interface{}, error) {
+	if i <= 0 || i > t.n {
+		return nil, fenwickQueryError(t.n, i)
+	}
+	return t.prefix(i), nil
+}
+
+// Add combines value into the element stored at the given index.
+func (t *GroupFenwickTree) Add(i int, value interface{}) error {
+	if i <= 0 || i > t.n {
+		return fenwickIndexError(i)
+	}
+	for i <= t.n {
+		t.bit[i] = t.g.Op(t.bit[i], value)
+		i += i & (-i)
+	}
 	return nil
 }
+
+// RangeSum returns the combination of the elements in the closed range [l, r].
+func (t *GroupFenwickTree) RangeSum(l, r int) (interface{}, error) {
+	if l <= 0 || r > t.n || l > r {
+		return nil, fenwickRangeError(t.n, l, r)
+	}
+	return t.g.Op(t.prefix(r), t.g.Inverse(t.prefix(l-1))), nil
+}
+
+// PointGet returns the element stored at the given index.
+func (t *GroupFenwickTree) PointGet(i int) (interface{}, error) {
+	if i <= 0 || i > t.n {
+		return nil, fenwickIndexError(i)
+	}
+	return t.RangeSum(i, i)
+}
+
+// Set the element stored at the given index.
+func (t *GroupFenwickTree) Set(i int, value interface{}) error {
+	old, err := t.PointGet(i)
+	if err != nil {
+		return err
+	}
+	return t.Add(i, t.g.Op(value, t.g.Inverse(old)))
+}
+
+// Size returns the number of elements in the tree.
+func (t *GroupFenwickTree) Size() int {
+	return t.n
+}
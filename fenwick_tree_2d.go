@@ -0,0 +1,65 @@
+package ads
+
+import "fmt"
+
+// FenwickTree2D is a two-dimensional binary indexed tree for computing rectangle sums over a grid
+// with point updates.
+// Important: this implementation is one-indexed.
+type FenwickTree2D struct {
+	bit        [][]int
+	rows, cols int
+}
+
+// NewFenwickTree2D returns a zero-initialized Fenwick Tree over a grid of the given dimensions.
+func NewFenwickTree2D(rows, cols int) *FenwickTree2D {
+	t := &FenwickTree2D{rows: rows, cols: cols, bit: make([][]int, rows+1)}
+	for i := range t.bit {
+		t.bit[i] = make([]int, cols+1)
+	}
+	return t
+}
+
+func (t *FenwickTree2D) validCell(x, y int) bool {
+	return x > 0 && x <= t.rows && y > 0 && y <= t.cols
+}
+
+// prefix returns the sum of the rectangle between (1, 1) and (x, y) without validating them.
+func (t *FenwickTree2D) prefix(x, y int) int {
+	s := 0
+	for i := x; i > 0; i -= i & (-i) {
+		for j := y; j > 0; j -= j & (-j) {
+			s += t.bit[i][j]
+		}
+	}
+	return s
+}
+
+// Get returns the sum of the rectangle between (1, 1) and (x, y).
+func (t *FenwickTree2D) Get(x, y int) (int, error) {
+	if !t.validCell(x, y) {
+		return 0, fmt.Errorf("tree size is %dx%d, got query for (%d, %d)", t.rows, t.cols, x, y)
+	}
+	return t.prefix(x, y), nil
+}
+
+// Add value at the given cell.
+func (t *FenwickTree2D) Add(x, y, value int) error {
+	if !t.validCell(x, y) {
+		return fmt.Errorf("invalid index (%d, %d)", x, y)
+	}
+	for i := x; i <= t.rows; i += i & (-i) {
+		for j := y; j <= t.cols; j += j & (-j) {
+			t.bit[i][j] += value
+		}
+	}
+	return nil
+}
+
+// RectSum returns the sum of the rectangle with corners (x1, y1) and (x2, y2), both inclusive.
+func (t *FenwickTree2D) RectSum(x1, y1, x2, y2 int) (int, error) {
+	if !t.validCell(x1, y1) || !t.validCell(x2, y2) || x1 > x2 || y1 > y2 {
+		return 0, fmt.Errorf("tree size is %dx%d, got invalid rectangle (%d, %d)-(%d, %d)",
+			t.rows, t.cols, x1, y1, x2, y2)
+	}
+	return t.prefix(x2, y2) - t.prefix(x1-1, y2) - t.prefix(x2, y1-1) + t.prefix(x1-1, y1-1), nil
+}
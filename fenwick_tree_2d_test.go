@@ -0,0 +1,105 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// bruteForcePrefix2D returns the 2D prefix sum array of the given one-indexed grid.
+func bruteForcePrefix2D(grid [][]int) [][]int {
+	prefix := make([][]int, len(grid))
+	for i := range grid {
+		prefix[i] = make([]int, len(grid[i]))
+		for j := range grid[i] {
+			if i == 0 || j == 0 {
+				continue
+			}
+			prefix[i][j] = grid[i][j] + prefix[i-1][j] + prefix[i][j-1] - prefix[i-1][j-1]
+		}
+	}
+	return prefix
+}
+
+func TestFenwickTree2D(t *testing.T) {
+	sizes := []struct{ rows, cols int }{{1, 1}, {1, 10}, {10, 1}, {5, 7}, {16, 16}, {33, 20}}
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("%dx%d", size.rows, size.cols), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(size.rows*100 + size.cols)))
+			fwt := NewFenwickTree2D(size.rows, size.cols)
+			grid := make([][]int, size.rows+1)
+			for i := range grid {
+				grid[i] = make([]int, size.cols+1)
+			}
+			for k := 0; k < 50; k++ {
+				x, y, v := r.Intn(size.rows)+1, r.Intn(size.cols)+1, r.Intn(201)-100
+				if err := fwt.Add(x, y, v); err != nil {
+					t.Fatalf("Add(%d, %d, %d) returned unexpected error; %v", x, y, v, err)
+				}
+				grid[x][y] += v
+				prefix := bruteForcePrefix2D(grid)
+				for x := 1; x <= size.rows; x++ {
+					for y := 1; y <= size.cols; y++ {
+						got, err := fwt.Get(x, y)
+						if err != nil {
+							t.Fatalf("Get(%d, %d) returned unexpected error; %v", x, y, err)
+						}
+						if got != prefix[x][y] {
+							t.Fatalf("Get(%d, %d): %d, want %d", x, y, got, prefix[x][y])
+						}
+					}
+				}
+				x1, y1 := r.Intn(size.rows)+1, r.Intn(size.cols)+1
+				x2, y2 := x1+r.Intn(size.rows-x1+1), y1+r.Intn(size.cols-y1+1)
+				want := prefix[x2][y2] - prefix[x1-1][y2] - prefix[x2][y1-1] + prefix[x1-1][y1-1]
+				got, err := fwt.RectSum(x1, y1, x2, y2)
+				if err != nil {
+					t.Fatalf("RectSum(%d, %d, %d, %d) returned unexpected error; %v", x1, y1, x2, y2, err)
+				}
+				if got != want {
+					t.Fatalf("RectSum(%d, %d, %d, %d): %d, want %d", x1, y1, x2, y2, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFenwickTree2D_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows, cols int
+		cells      [][2]int
+		rects      [][4]int
+	}{
+		{
+			name:  "operating on empty tree",
+			cells: [][2]int{{1, 1}, {0, 0}},
+			rects: [][4]int{{1, 1, 1, 1}},
+		},
+		{
+			name:  "invalid indexes",
+			rows:  4,
+			cols:  6,
+			cells: [][2]int{{0, 1}, {1, 0}, {5, 1}, {1, 7}, {-1, -1}},
+			rects: [][4]int{{0, 1, 2, 2}, {1, 1, 5, 6}, {1, 1, 4, 7}, {3, 1, 2, 6}, {1, 4, 4, 3}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fwt := NewFenwickTree2D(test.rows, test.cols)
+			for _, c := range test.cells {
+				if err := fwt.Add(c[0], c[1], 1); err == nil {
+					t.Errorf("Add(%d, %d, 1) returned nil error, want error", c[0], c[1])
+				}
+				if _, err := fwt.Get(c[0], c[1]); err == nil {
+					t.Errorf("Get(%d, %d) returned nil error, want error", c[0], c[1])
+				}
+			}
+			for _, rc := range test.rects {
+				if _, err := fwt.RectSum(rc[0], rc[1], rc[2], rc[3]); err == nil {
+					t.Errorf("RectSum(%d, %d, %d, %d) returned nil error, want error", rc[0], rc[1], rc[2], rc[3])
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package ads
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
+var fwtUnxOpt = cmp.AllowUnexported(FenwickTree{})
+
 type fwtOpType uint
 
 const (
@@ -133,3 +139,393 @@ func TestFenwickTree_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestFenwickTree_RangeQueries(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 100} {
+		t.Run(fmt.Sprintf("size %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			fwt := NewFenwickTree(n)
+			control := make([]int, n+1)
+			for k := 0; k < 5*n; k++ {
+				i, v := r.Intn(n)+1, r.Intn(201)-100
+				if k%2 == 0 {
+					if err := fwt.Add(i, v); err != nil {
+						t.Fatalf("Add(%d, %d) returned unexpected error; %v", i, v, err)
+					}
+					control[i] += v
+				} else {
+					if err := fwt.Set(i, v); err != nil {
+						t.Fatalf("Set(%d, %d) returned unexpected error; %v", i, v, err)
+					}
+					control[i] = v
+				}
+				for i := 1; i <= n; i++ {
+					got, err := fwt.PointGet(i)
+					if err != nil {
+						t.Fatalf("PointGet(%d) returned unexpected error; %v", i, err)
+					}
+					if got != control[i] {
+						t.Fatalf("PointGet(%d): %d, want %d", i, got, control[i])
+					}
+				}
+				l := r.Intn(n) + 1
+				rr := l + r.Intn(n-l+1)
+				want := 0
+				for i := l; i <= rr; i++ {
+					want += control[i]
+				}
+				got, err := fwt.RangeSum(l, rr)
+				if err != nil {
+					t.Fatalf("RangeSum(%d, %d) returned unexpected error; %v", l, rr, err)
+				}
+				if got != want {
+					t.Fatalf("RangeSum(%d, %d): %d, want %d", l, rr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFenwickTree_LowerBound(t *testing.T) {
+	values := []int{3, 0, 2, 5, 0, 0, 1, 4}
+	fwt := NewFenwickTree(len(values))
+	for i, v := range values {
+		if err := fwt.Add(i+1, v); err != nil {
+			t.Fatalf("Add(%d, %d) returned unexpected error; %v", i+1, v, err)
+		}
+	}
+	tests := []struct {
+		sum, want int
+	}{
+		{sum: -1, want: 1}, {sum: 0, want: 1}, {sum: 1, want: 1}, {sum: 3, want: 1},
+		{sum: 4, want: 3}, {sum: 5, want: 3}, {sum: 6, want: 4}, {sum: 10, want: 4},
+		{sum: 11, want: 7}, {sum: 12, want: 8}, {sum: 15, want: 8},
+	}
+	for _, test := range tests {
+		got, err := fwt.LowerBound(test.sum)
+		if err != nil {
+			t.Fatalf("LowerBound(%d) returned unexpected error; %v", test.sum, err)
+		}
+		if got != test.want {
+			t.Errorf("LowerBound(%d): %d, want %d", test.sum, got, test.want)
+		}
+	}
+	if _, err := fwt.LowerBound(16); err == nil {
+		t.Error("LowerBound(16) returned nil error, want error")
+	}
+	if _, err := NewFenwickTree(0).LowerBound(0); err == nil {
+		t.Error("LowerBound(0) on empty tree returned nil error, want error")
+	}
+}
+
+func TestFenwickTree_RangeErrors(t *testing.T) {
+	fwt := NewFenwickTree(10)
+	for _, rng := range [][2]int{{0, 5}, {1, 11}, {6, 5}, {0, 0}} {
+		if _, err := fwt.RangeSum(rng[0], rng[1]); err == nil {
+			t.Errorf("RangeSum(%d, %d) returned nil error, want error", rng[0], rng[1])
+		}
+	}
+	for _, i := range []int{0, 11} {
+		if _, err := fwt.PointGet(i); err == nil {
+			t.Errorf("PointGet(%d) returned nil error, want error", i)
+		}
+		if err := fwt.Set(i, 1); err == nil {
+			t.Errorf("Set(%d, 1) returned nil error, want error", i)
+		}
+	}
+}
+
+func TestRangeFenwickTree(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 100} {
+		t.Run(fmt.Sprintf("size %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			fwt := NewRangeFenwickTree(n)
+			control := make([]int, n+1)
+			for k := 0; k < 5*n; k++ {
+				l := r.Intn(n) + 1
+				rr := l + r.Intn(n-l+1)
+				v := r.Intn(201) - 100
+				if err := fwt.AddRange(l, rr, v); err != nil {
+					t.Fatalf("AddRange(%d, %d, %d) returned unexpected error; %v", l, rr, v, err)
+				}
+				for i := l; i <= rr; i++ {
+					control[i] += v
+				}
+				i := r.Intn(n) + 1
+				if err := fwt.Add(i, v); err != nil {
+					t.Fatalf("Add(%d, %d) returned unexpected error; %v", i, v, err)
+				}
+				control[i] += v
+				want := 0
+				for i := 1; i <= n; i++ {
+					want += control[i]
+					got, err := fwt.Get(i)
+					if err != nil {
+						t.Fatalf("Get(%d) returned unexpected error; %v", i, err)
+					}
+					if got != want {
+						t.Fatalf("Get(%d): %d, want %d", i, got, want)
+					}
+					if got, _ := fwt.PointGet(i); got != control[i] {
+						t.Fatalf("PointGet(%d): %d, want %d", i, got, control[i])
+					}
+				}
+				l = r.Intn(n) + 1
+				rr = l + r.Intn(n-l+1)
+				want = 0
+				for i := l; i <= rr; i++ {
+					want += control[i]
+				}
+				if got, _ := fwt.RangeSum(l, rr); got != want {
+					t.Fatalf("RangeSum(%d, %d): %d, want %d", l, rr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeFenwickTree_Errors(t *testing.T) {
+	fwt := NewRangeFenwickTree(10)
+	for _, rng := range [][2]int{{0, 5}, {1, 11}, {6, 5}} {
+		if err := fwt.AddRange(rng[0], rng[1], 1); err == nil {
+			t.Errorf("AddRange(%d, %d, 1) returned nil error, want error", rng[0], rng[1])
+		}
+		if _, err := fwt.RangeSum(rng[0], rng[1]); err == nil {
+			t.Errorf("RangeSum(%d, %d) returned nil error, want error", rng[0], rng[1])
+		}
+	}
+	for _, i := range []int{0, 11} {
+		if err := fwt.Add(i, 1); err == nil {
+			t.Errorf("Add(%d, 1) returned nil error, want error", i)
+		}
+		if _, err := fwt.Get(i); err == nil {
+			t.Errorf("Get(%d) returned nil error, want error", i)
+		}
+		if _, err := fwt.PointGet(i); err == nil {
+			t.Errorf("PointGet(%d) returned nil error, want error", i)
+		}
+	}
+}
+
+func TestNewFenwickTreeFrom(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 16, 100} {
+		t.Run(fmt.Sprintf("size %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			values := make([]int, n)
+			want := NewFenwickTree(n)
+			for i := range values {
+				values[i] = r.Intn(201) - 100
+				want.Add(i+1, values[i])
+			}
+			got := NewFenwickTreeFrom(values)
+			if diff := cmp.Diff(want, got, fwtUnxOpt); diff != "" {
+				t.Fatalf("NewFenwickTreeFrom(%v) produced unwanted tree, diff want -> got\n%s", values, diff)
+			}
+			if diff := cmp.Diff(values, got.Values()); diff != "" {
+				t.Errorf("Values() produced unwanted values, diff want -> got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFenwickTree_Append(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := []int{}
+	fwt := NewFenwickTree(0)
+	for i := 0; i < 100; i++ {
+		v := r.Intn(201) - 100
+		values = append(values, v)
+		fwt.Append(v)
+		if fwt.Size() != len(values) {
+			t.Fatalf("Size(): %d, want %d", fwt.Size(), len(values))
+		}
+		if diff := cmp.Diff(NewFenwickTreeFrom(values), fwt, fwtUnxOpt); diff != "" {
+			t.Fatalf("Append(%d) produced unwanted tree, diff want -> got\n%s", v, diff)
+		}
+	}
+}
+
+func TestZeroIndexedFenwickTree(t *testing.T) {
+	fwt := NewZeroIndexedFenwickTree(5)
+	for i, v := range []int{3, 1, 4, 1, 5} {
+		if err := fwt.Add(i, v); err != nil {
+			t.Fatalf("Add(%d, %d) returned unexpected error; %v", i, v, err)
+		}
+	}
+	fwt.Append(9)
+	if err := fwt.Set(1, 2); err != nil {
+		t.Fatalf("Set(1, 2) returned unexpected error; %v", err)
+	}
+	values := []int{3, 2, 4, 1, 5, 9}
+	if diff := cmp.Diff(values, fwt.Values()); diff != "" {
+		t.Errorf("Values() produced unwanted values, diff want -> got\n%s", diff)
+	}
+	prefix := 0
+	for i, v := range values {
+		prefix += v
+		if got, err := fwt.Get(i); err != nil || got != prefix {
+			t.Errorf("Get(%d): %d, %v, want %d, nil", i, got, err, prefix)
+		}
+		if got, err := fwt.PointGet(i); err != nil || got != v {
+			t.Errorf("PointGet(%d): %d, %v, want %d, nil", i, got, err, v)
+		}
+	}
+	if got, err := fwt.RangeSum(1, 3); err != nil || got != 7 {
+		t.Errorf("RangeSum(1, 3): %d, %v, want 7, nil", got, err)
+	}
+	for sum, want := range map[int]int{0: 0, 3: 0, 4: 1, 9: 2, 15: 4, 24: 5} {
+		if got, err := fwt.LowerBound(sum); err != nil || got != want {
+			t.Errorf("LowerBound(%d): %d, %v, want %d, nil", sum, got, err, want)
+		}
+	}
+	if _, err := fwt.LowerBound(25); err == nil {
+		t.Error("LowerBound(25) returned nil error, want error")
+	}
+}
+
+func TestZeroIndexedFenwickTree_Errors(t *testing.T) {
+	fwt := NewFenwickTree(10)
+	zfwt := fwt.ZeroIndexed()
+	for _, i := range []int{-1, 10} {
+		if err := zfwt.Add(i, 1); err == nil {
+			t.Errorf("Add(%d, 1) returned nil error, want error", i)
+		}
+		if _, err := zfwt.Get(i); err == nil {
+			t.Errorf("Get(%d) returned nil error, want error", i)
+		}
+		if _, err := zfwt.PointGet(i); err == nil {
+			t.Errorf("PointGet(%d) returned nil error, want error", i)
+		}
+		if err := zfwt.Set(i, 1); err == nil {
+			t.Errorf("Set(%d, 1) returned nil error, want error", i)
+		}
+	}
+	for _, rng := range [][2]int{{-1, 5}, {0, 10}, {6, 5}} {
+		if _, err := zfwt.RangeSum(rng[0], rng[1]); err == nil {
+			t.Errorf("RangeSum(%d, %d) returned nil error, want error", rng[0], rng[1])
+		}
+	}
+	// Prefix queries are reported by their number of elements, as in the one-indexed tree.
+	_, oneErr := fwt.Get(11)
+	_, zeroErr := zfwt.Get(10)
+	if oneErr.Error() != zeroErr.Error() {
+		t.Errorf("Get(10) error: %q, want %q", zeroErr, oneErr)
+	}
+	if i, err := zfwt.LowerBound(1); err == nil || i != 0 {
+		t.Errorf("LowerBound(1): %d, %v, want 0 and an error", i, err)
+	}
+}
+
+func TestGroupFenwickTree(t *testing.T) {
+	mod, _ := NewModularGroup(1000000007)
+	tests := []struct {
+		name string
+		g    Group
+		gen  func(r *rand.Rand) interface{}
+	}{
+		{name: "int64", g: Int64Group{}, gen: func(r *rand.Rand) interface{} { return r.Int63n(201) - 100 }},
+		{name: "modular", g: mod, gen: func(r *rand.Rand) interface{} { return mod.Reduce(r.Int63()) }},
+		{name: "xor", g: XORGroup{}, gen: func(r *rand.Rand) interface{} { return r.Uint64() }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			const n = 50
+			r := rand.New(rand.NewSource(1))
+			fwt := NewGroupFenwickTree(test.g, n)
+			control := make([]interface{}, n+1)
+			for i := range control {
+				control[i] = test.g.Identity()
+			}
+			for k := 0; k < 200; k++ {
+				i, v := r.Intn(n)+1, test.gen(r)
+				if k%3 == 0 {
+					if err := fwt.Set(i, v); err != nil {
+						t.Fatalf("Set(%d, %v) returned unexpected error; %v", i, v, err)
+					}
+					control[i] = v
+				} else {
+					if err := fwt.Add(i, v); err != nil {
+						t.Fatalf("Add(%d, %v) returned unexpected error; %v", i, v, err)
+					}
+					control[i] = test.g.Op(control[i], v)
+				}
+				l := r.Intn(n) + 1
+				rr := l + r.Intn(n-l+1)
+				want := test.g.Identity()
+				for i := l; i <= rr; i++ {
+					want = test.g.Op(want, control[i])
+				}
+				if got, err := fwt.RangeSum(l, rr); err != nil || got != want {
+					t.Fatalf("RangeSum(%d, %d): %v, %v, want %v, nil", l, rr, got, err, want)
+				}
+				if got, err := fwt.PointGet(i); err != nil || got != control[i] {
+					t.Fatalf("PointGet(%d): %v, %v, want %v, nil", i, got, err, control[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGroupFenwickTree_UnreducedModularValues(t *testing.T) {
+	mod, _ := NewModularGroup(7)
+	fwt := NewGroupFenwickTree(mod, 3)
+	// -3 is 4 and 23 is 2 modulo 7.
+	if err := fwt.Add(1, int64(-3)); err != nil {
+		t.Fatalf("Add(1, -3) returned unexpected error; %v", err)
+	}
+	if err := fwt.Add(2, int64(23)); err != nil {
+		t.Fatalf("Add(2, 23) returned unexpected error; %v", err)
+	}
+	if err := fwt.Set(3, int64(-15)); err != nil {
+		t.Fatalf("Set(3, -15) returned unexpected error; %v", err)
+	}
+	for i, want := range []int64{4, 6, 5} {
+		if got, err := fwt.Get(i + 1); err != nil || got != want {
+			t.Errorf("Get(%d): %v, %v, want %d, nil", i+1, got, err, want)
+		}
+	}
+	if got, err := fwt.PointGet(3); err != nil || got != int64(6) {
+		t.Errorf("PointGet(3): %v, %v, want 6, nil", got, err)
+	}
+}
+
+func TestGroupFenwickTree_KahanSummation(t *testing.T) {
+	const n = 1001
+	fwt := NewGroupFenwickTree(KahanGroup{}, n)
+	fwt.Add(1, KahanSum{Sum: 1e16})
+	naive := 1e16
+	for i := 2; i <= n; i++ {
+		fwt.Add(i, KahanSum{Sum: 1})
+		naive++
+	}
+	got, err := fwt.Get(n)
+	if err != nil {
+		t.Fatalf("Get(%d) returned unexpected error; %v", n, err)
+	}
+	if want := 1e16 + 1000; got.(KahanSum).Float64() != want {
+		t.Errorf("Get(%d): %f, want %f (naive summation gives %f)", n, got.(KahanSum).Float64(), want, naive)
+	}
+}
+
+func TestGroupFenwickTree_Errors(t *testing.T) {
+	fwt := NewGroupFenwickTree(Int64Group{}, 10)
+	for _, i := range []int{0, 11} {
+		if err := fwt.Add(i, int64(1)); err == nil {
+			t.Errorf("Add(%d, 1) returned nil error, want error", i)
+		}
+		if _, err := fwt.Get(i); err == nil {
+			t.Errorf("Get(%d) returned nil error, want error", i)
+		}
+		if _, err := fwt.PointGet(i); err == nil {
+			t.Errorf("PointGet(%d) returned nil error, want error", i)
+		}
+		if err := fwt.Set(i, int64(1)); err == nil {
+			t.Errorf("Set(%d, 1) returned nil error, want error", i)
+		}
+	}
+	for _, rng := range [][2]int{{0, 5}, {1, 11}, {6, 5}} {
+		if _, err := fwt.RangeSum(rng[0], rng[1]); err == nil {
+			t.Errorf("RangeSum(%d, %d) returned nil error, want error", rng[0], rng[1])
+		}
+	}
+}
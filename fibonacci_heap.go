@@ -0,0 +1,241 @@
+package ads
+
+import "fmt"
+
+// fibonacciHeapNode is a node of a Fibonacci heap. Siblings are kept in a circular doubly linked
+// list; mark records whether the node lost a child since it became a child itself.
+type fibonacciHeapNode struct {
+	item                       *HeapItem
+	parent, child, left, right *fibonacciHeapNode
+	degree                     int
+	mark                       bool
+	owner                      *heapOwner
+}
+
+// FibonacciHeap is a Heap with amortized O(1) insertion, merging and key decreasing. See
+// Introduction to Algorithms (CLRS), chapter 19 for reference.
+type FibonacciHeap struct {
+	min    *fibonacciHeapNode
+	length int
+	owner  *heapOwner
+}
+
+// NewFibonacciHeap returns an empty Fibonacci heap.
+func NewFibonacciHeap() *FibonacciHeap {
+	return &FibonacciHeap{owner: &heapOwner{}}
+}
+
+// splice concatenates the circular lists that contain a and b.
+func (h *FibonacciHeap) splice(a, b *fibonacciHeapNode) {
+	a.right.left = b.left
+	b.left.right = a.right
+	a.right = b
+	b.left = a
+}
+
+// unlink removes n from its circular list, leaving it as a single-node list.
+func (h *FibonacciHeap) unlink(n *fibonacciHeapNode) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// addRoot inserts the single-node list n into the root list and updates the minimum.
+func (h *FibonacciHeap) addRoot(n *fibonacciHeapNode) {
+	n.parent = nil
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	h.splice(h.min, n)
+	if n.item.Key < h.min.item.Key {
+		h.min = n
+	}
+}
+
+// Insert a new value with the given key, returns a handle to the stored item.
+func (h *FibonacciHeap) Insert(key int, v interface{}) *HeapItem {
+	item := &HeapItem{Key: key, Value: v}
+	n := &fibonacciHeapNode{item: item, owner: h.owner}
+	n.left, n.right = n, n
+	item.node = n
+	h.addRoot(n)
+	h.length++
+	return item
+}
+
+// FindMin returns the item with the smallest key without removing it.
+func (h *FibonacciHeap) FindMin() (*HeapItem, error) {
+	if h.min == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	return h.min.item, nil
+}
+
+// DeleteMin removes and returns the item with the smallest key.
+func (h *FibonacciHeap) DeleteMin() (*HeapItem, error) {
+	if h.min == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	z := h.min
+	// Promote every child of z to the root list.
+	if c := z.child; c != nil {
+		for x := c; ; x = x.right {
+			x.parent = nil
+			if x.right == c {
+				break
+			}
+		}
+		h.splice(z, c)
+		z.child = nil
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.unlink(z)
+		h.consolidate()
+	}
+	h.length--
+	z.item.node = nil
+	return z.item, nil
+}
+
+// consolidate links roots of equal degree until every root has a distinct degree.
+func (h *FibonacciHeap) consolidate() {
+	var roots []*fibonacciHeapNode
+	for x := h.min; ; x = x.right {
+		roots = append(roots, x)
+		if x.right == h.min {
+			break
+		}
+	}
+	var byDegree []*fibonacciHeapNode
+	for _, x := range roots {
+		h.unlink(x)
+		for {
+			for len(byDegree) <= x.degree {
+				byDegree = append(byDegree, nil)
+			}
+			y := byDegree[x.degree]
+			if y == nil {
+				break
+			}
+			byDegree[x.degree] = nil
+			if y.item.Key < x.item.Key {
+				x, y = y, x
+			}
+			// Make y a child of x.
+			if x.child == nil {
+				x.child = y
+			} else {
+				h.splice(x.child, y)
+			}
+			y.parent = x
+			y.mark = false
+			x.degree++
+		}
+		byDegree[x.degree] = x
+	}
+	h.min = nil
+	for _, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+// DecreaseKey lowers the key of an item stored in the heap.
+func (h *FibonacciHeap) DecreaseKey(item *HeapItem, key int) error {
+	if err := validateDecreaseKey(item, key); err != nil {
+		return err
+	}
+	x, ok := item.node.(*fibonacciHeapNode)
+	if !ok || x.item != item || x.owner.find() != h.owner {
+		return fmt.Errorf("item %v is not stored in the heap", item)
+	}
+	item.Key = key
+	if y := x.parent; y != nil && x.item.Key < y.item.Key {
+		h.cut(x, y)
+		h.cascadingCut(y)
+	}
+	if x.item.Key < h.min.item.Key {
+		h.min = x
+	}
+	return nil
+}
+
+// cut moves x, a child of y, to the root list.
+func (h *FibonacciHeap) cut(x, y *fibonacciHeapNode) {
+	if x.right == x {
+		y.child = nil
+	} else if y.child == x {
+		y.child = x.right
+	}
+	h.unlink(x)
+	y.degree--
+	x.mark = false
+	h.addRoot(x)
+}
+
+// cascadingCut cuts marked ancestors of y until an unmarked one (or a root) is found.
+func (h *FibonacciHeap) cascadingCut(y *fibonacciHeapNode) {
+	for z := y.parent; z != nil; y, z = z, z.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+	}
+}
+
+// Merge moves all the items of the given heap into this one, leaving the other heap empty.
+func (h *FibonacciHeap) Merge(other Heap) error {
+	o, ok := other.(*FibonacciHeap)
+	if !ok {
+		return fmt.Errorf("cannot merge %T into %T", other, h)
+	}
+	if o == h || o.min == nil {
+		return nil
+	}
+	o.owner.parent = h.owner
+	if h.min == nil {
+		h.min = o.min
+	} else {
+		h.splice(h.min, o.min)
+		if o.min.item.Key < h.min.item.Key {
+			h.min = o.min
+		}
+	}
+	h.length += o.length
+	o.min, o.length, o.owner = nil, 0, &heapOwner{}
+	return nil
+}
+
+// Size returns the number of elements stored in the heap.
+func (h *FibonacciHeap) Size() int {
+	return h.length
+}
+
+// Empty removes all elements from the heap.
+func (h *FibonacciHeap) Empty() {
+	// Detach every item from its node so stale handles are rejected by DecreaseKey.
+	var stack []*fibonacciHeapNode
+	if h.min != nil {
+		stack = append(stack, h.min)
+	}
+	for len(stack) > 0 {
+		first := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for x := first; ; x = x.right {
+			x.item.node = nil
+			if x.child != nil {
+				stack = append(stack, x.child)
+			}
+			if x.right == first {
+				break
+			}
+		}
+	}
+	h.min, h.length = nil, 0
+}
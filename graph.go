@@ -0,0 +1,408 @@
+package ads
+
+import "fmt"
+
+// GraphFlags configure the kind of graph built by NewGraph. Flags are combined with |.
+type GraphFlags int
+
+const (
+	// Directed graphs store each edge only in the adjacency of its source vertex.
+	Directed GraphFlags = 1 << iota
+	// Weighted graphs accept arbitrary integer weights, otherwise every edge weighs 1.
+	Weighted
+	// ArrayAdjacency stores adjacencies in Arrays instead of Lists.
+	ArrayAdjacency
+)
+
+// Edge is a connection from a vertex to another one. Edges of undirected graphs are reported
+// from the vertex whose adjacency is being read.
+type Edge struct {
+	From, To int
+	Weight   int
+}
+
+// Graph is a graph over the vertices 0, ..., n-1 represented with adjacency lists. Parallel edges
+// and self-loops are allowed.
+type Graph struct {
+	flags GraphFlags
+	// adj holds the outgoing Edges of each vertex in a List or an Array.
+	adj   []Container
+	edges int
+}
+
+// NewGraph returns a graph of n vertices with no edges.
+func NewGraph(n int, flags GraphFlags) *Graph {
+	g := &Graph{flags: flags}
+	for i := 0; i < n; i++ {
+		g.AddVertex()
+	}
+	return g
+}
+
+func graphVertexError(n, v int) error {
+	return fmt.Errorf("invalid vertex %d, graph has %d vertices", v, n)
+}
+
+func (g *Graph) validate(vs ...int) error {
+	for _, v := range vs {
+		if v < 0 || v >= len(g.adj) {
+			return graphVertexError(len(g.adj), v)
+		}
+	}
+	return nil
+}
+
+// Directed returns whether the graph is directed.
+func (g *Graph) Directed() bool {
+	return g.flags&Directed != 0
+}
+
+// Weighted returns whether the graph is weighted.
+func (g *Graph) Weighted() bool {
+	return g.flags&Weighted != 0
+}
+
+// Order returns the number of vertices of the graph.
+func (g *Graph) Order() int {
+	return len(g.adj)
+}
+
+// Size returns the number of edges of the graph.
+func (g *Graph) Size() int {
+	return g.edges
+}
+
+// AddVertex inserts a new isolated vertex and returns it.
+func (g *Graph) AddVertex() int {
+	if g.flags&ArrayAdjacency != 0 {
+		g.adj = append(g.adj, NewArray())
+	} else {
+		g.adj = append(g.adj, NewList())
+	}
+	return len(g.adj) - 1
+}
+
+// AddEdge inserts an edge of weight 1 between u and v.
+func (g *Graph) AddEdge(u, v int) error {
+	if err := g.validate(u, v); err != nil {
+		return err
+	}
+	g.addEdge(u, v, 1)
+	return nil
+}
+
+// AddWeightedEdge inserts an edge of the given weight between u and v. The graph must be weighted.
+func (g *Graph) AddWeightedEdge(u, v, weight int) error {
+	if !g.Weighted() {
+		return fmt.Errorf("cannot add a weighted edge to an unweighted graph")
+	}
+	if err := g.validate(u, v); err != nil {
+		return err
+	}
+	g.addEdge(u, v, weight)
+	return nil
+}
+
+func (g *Graph) addEdge(u, v, weight int) {
+	g.adj[u].Add(Edge{From: u, To: v, Weight: weight})
+	if !g.Directed() && u != v {
+		g.adj[v].Add(Edge{From: v, To: u, Weight: weight})
+	}
+	g.edges++
+}
+
+// RemoveEdge deletes every edge between u and v.
+func (g *Graph) RemoveEdge(u, v int) error {
+	if err := g.validate(u, v); err != nil {
+		return err
+	}
+	var found []Edge
+	for it := g.adj[u].Iterator(); it.Scan(); {
+		if e := graphNextEdge(it); e.To == v {
+			found = append(found, e)
+		}
+	}
+	size := g.adj[u].Size()
+	for _, e := range found {
+		// Containers delete every occurrence, so repeated edges are already gone.
+		g.adj[u].Remove(e)
+		if !g.Directed() && u != v {
+			g.adj[v].Remove(Edge{From: v, To: u, Weight: e.Weight})
+		}
+	}
+	g.edges -= size - g.adj[u].Size()
+	return nil
+}
+
+// HasEdge returns whether there's an edge between u and v.
+func (g *Graph) HasEdge(u, v int) (bool, error) {
+	if err := g.validate(u, v); err != nil {
+		return false, err
+	}
+	for it := g.adj[u].Iterator(); it.Scan(); {
+		if graphNextEdge(it).To == v {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Neighbors returns an iterable over the Edges leaving u.
+func (g *Graph) Neighbors(u int) (Iterable, error) {
+	if err := g.validate(u); err != nil {
+		return nil, err
+	}
+	return g.adj[u].Iterator(), nil
+}
+
+// Degree returns the number of edges leaving u. Self-loops of undirected graphs count once.
+func (g *Graph) Degree(u int) (int, error) {
+	if err := g.validate(u); err != nil {
+		return 0, err
+	}
+	return g.adj[u].Size(), nil
+}
+
+// Edges returns an iterable over every Edge of the graph. Edges of undirected graphs are reported
+// once, from their smallest endpoint.
+func (g *Graph) Edges() Iterable {
+	edges := NewArray()
+	for u := range g.adj {
+		for it := g.adj[u].Iterator(); it.Scan(); {
+			if e := graphNextEdge(it); g.Directed() || e.From <= e.To {
+				edges.Add(e)
+			}
+		}
+	}
+	return edges.Iterator()
+}
+
+// graphNextEdge returns the next Edge of an adjacency iterable known to have one.
+func graphNextEdge(it Iterable) Edge {
+	e, _ := it.Next()
+	return e.(Edge)
+}
+
+// Traversal is the result of a graph search: the order in which vertices were visited and the
+// tree (or forest) of the edges used to discover them.
+type Traversal struct {
+	order  *Array
+	parent []int
+}
+
+func newTraversal(n int) *Traversal {
+	t := &Traversal{order: NewArray(), parent: make([]int, n)}
+	for i := range t.parent {
+		t.parent[i] = -2
+	}
+	return t
+}
+
+// visit marks v as discovered from p, -1 if v is a root.
+func (t *Traversal) visit(v, p int) {
+	t.parent[v] = p
+	t.order.Add(v)
+}
+
+// Order returns an iterable over the visited vertices in visit order.
+func (t *Traversal) Order() Iterable {
+	return t.order.Iterator()
+}
+
+// Visited returns whether v was reached by the search.
+func (t *Traversal) Visited(v int) bool {
+	return v >= 0 && v < len(t.parent) && t.parent[v] != -2
+}
+
+// Parent returns the vertex from which v was discovered. Returns -1, false if v is a root of the
+// search or wasn't reached.
+func (t *Traversal) Parent(v int) (int, bool) {
+	if !t.Visited(v) || t.parent[v] == -1 {
+		return -1, false
+	}
+	return t.parent[v], true
+}
+
+// PathTo returns the vertices on the tree path from the root of the search to v.
+func (t *Traversal) PathTo(v int) ([]int, error) {
+	if !t.Visited(v) {
+		return nil, fmt.Errorf("vertex %d wasn't reached", v)
+	}
+	var path []int
+	for ; v != -1; v = t.parent[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// BFS visits the vertices reachable from src in breadth-first order, so tree paths are shortest in
+// number of edges.
+func (g *Graph) BFS(src int) (*Traversal, error) {
+	if err := g.validate(src); err != nil {
+		return nil, err
+	}
+	t := newTraversal(len(g.adj))
+	// Every vertex is enqueued at most once.
+	q := NewArrayBasedQueue(uint(len(g.adj)))
+	t.visit(src, -1)
+	q.Push(src)
+	for q.Size() > 0 {
+		u, _ := q.Pop()
+		for it := g.adj[u.(int)].Iterator(); it.Scan(); {
+			if e := graphNextEdge(it); !t.Visited(e.To) {
+				t.visit(e.To, e.From)
+				q.Push(e.To)
+			}
+		}
+	}
+	return t, nil
+}
+
+// dfsFrame is a vertex on the DFS stack and the iterable over the edges it has yet to explore.
+type dfsFrame struct {
+	v     int
+	edges Iterable
+}
+
+// DFS visits the vertices reachable from src in depth-first preorder.
+func (g *Graph) DFS(src int) (*Traversal, error) {
+	if err := g.validate(src); err != nil {
+		return nil, err
+	}
+	t := newTraversal(len(g.adj))
+	g.dfs(src, t)
+	return t, nil
+}
+
+// DFSForest visits every vertex in depth-first preorder, starting a new tree from the smallest
+// unvisited vertex whenever the current one is exhausted.
+func (g *Graph) DFSForest() *Traversal {
+	t := newTraversal(len(g.adj))
+	for v := range g.adj {
+		if !t.Visited(v) {
+			g.dfs(v, t)
+		}
+	}
+	return t
+}
+
+// dfs extends the traversal with a depth-first tree rooted at src. The stack holds the current
+// path, so it never exceeds the number of vertices.
+func (g *Graph) dfs(src int, t *Traversal) {
+	s := NewArrayBasedStack(uint(len(g.adj)))
+	t.visit(src, -1)
+	s.Push(&dfsFrame{v: src, edges: g.adj[src].Iterator()})
+	for s.Size() > 0 {
+		top, _ := s.Top()
+		f := top.(*dfsFrame)
+		if !f.edges.Scan() {
+			s.Pop()
+			continue
+		}
+		if e := graphNextEdge(f.edges); !t.Visited(e.To) {
+			t.visit(e.To, e.From)
+			s.Push(&dfsFrame{v: e.To, edges: g.adj[e.To].Iterator()})
+		}
+	}
+}
+
+// ConnectedComponents labels each vertex with the index of its connected component, numbered in
+// order of their smallest vertex, and returns the number of components. Edge directions are
+// ignored, so directed graphs get their weakly connected components.
+func (g *Graph) ConnectedComponents() ([]int, int) {
+	u := g
+	if g.Directed() {
+		u = g.undirected()
+	}
+	component := make([]int, len(g.adj))
+	count := 0
+	visited := newTraversal(len(g.adj))
+	for v := range g.adj {
+		if visited.Visited(v) {
+			continue
+		}
+		// The vertices of the component are the ones appended to the visit order by the search.
+		first := visited.order.Size()
+		u.dfs(v, visited)
+		for i := first; i < visited.order.Size(); i++ {
+			w, _ := visited.order.Get(i)
+			component[w.(int)] = count
+		}
+		count++
+	}
+	return component, count
+}
+
+// undirected returns a copy of the graph where every edge can be followed both ways.
+func (g *Graph) undirected() *Graph {
+	u := NewGraph(len(g.adj), g.flags&^Directed)
+	for it := g.Edges(); it.Scan(); {
+		e := graphNextEdge(it)
+		u.addEdge(e.From, e.To, e.Weight)
+	}
+	return u
+}
+
+// FindCycle returns the vertices of a cycle of the graph in order, or nil, false if the graph is
+// acyclic. Self-loops are cycles of one vertex and, in undirected graphs, parallel edges are
+// cycles of two vertices.
+func (g *Graph) FindCycle() ([]int, bool) {
+	const (
+		white = iota // not visited yet
+		gray         // on the current DFS path
+		black        // finished
+	)
+	color := make([]int, len(g.adj))
+	parent := make([]int, len(g.adj))
+	// skipped tracks whether the edge to the DFS parent was already ignored once, so a parallel
+	// edge back to it is reported as a cycle in undirected graphs.
+	skipped := make([]bool, len(g.adj))
+	s := NewArrayBasedStack(uint(len(g.adj)))
+	for src := range g.adj {
+		if color[src] != white {
+			continue
+		}
+		color[src], parent[src] = gray, -1
+		s.Push(&dfsFrame{v: src, edges: g.adj[src].Iterator()})
+		for s.Size() > 0 {
+			top, _ := s.Top()
+			f := top.(*dfsFrame)
+			if !f.edges.Scan() {
+				color[f.v] = black
+				s.Pop()
+				continue
+			}
+			e := graphNextEdge(f.edges)
+			if !g.Directed() && e.To == parent[e.From] && !skipped[e.From] {
+				skipped[e.From] = true
+				continue
+			}
+			switch color[e.To] {
+			case white:
+				color[e.To], parent[e.To] = gray, e.From
+				s.Push(&dfsFrame{v: e.To, edges: g.adj[e.To].Iterator()})
+			case gray:
+				// e closes a cycle with the tree path from e.To to e.From.
+				var cycle []int
+				for v := e.From; v != e.To; v = parent[v] {
+					cycle = append(cycle, v)
+				}
+				cycle = append(cycle, e.To)
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// HasCycle returns whether the graph has a cycle.
+func (g *Graph) HasCycle() bool {
+	_, ok := g.FindCycle()
+	return ok
+}
@@ -0,0 +1,321 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// graphStorages lists the adjacency containers every graph test runs with.
+var graphStorages = []struct {
+	name  string
+	flags GraphFlags
+}{
+	{name: "list", flags: 0},
+	{name: "array", flags: ArrayAdjacency},
+}
+
+// newTestGraph returns a graph of n vertices with the given edges.
+func newTestGraph(t *testing.T, n int, flags GraphFlags, edges [][2]int) *Graph {
+	t.Helper()
+	g := NewGraph(n, flags)
+	for _, e := range edges {
+		if err := g.AddEdge(e[0], e[1]); err != nil {
+			t.Fatalf("AddEdge(%d, %d) returned unexpected error; %v", e[0], e[1], err)
+		}
+	}
+	return g
+}
+
+// randomGraph returns a graph of n vertices with m random edges, weighted in [0, maxWeight).
+func randomGraph(r *rand.Rand, n, m, maxWeight int, flags GraphFlags) *Graph {
+	g := NewGraph(n, flags|Weighted)
+	for i := 0; i < m; i++ {
+		g.AddWeightedEdge(r.Intn(n), r.Intn(n), r.Intn(maxWeight))
+	}
+	return g
+}
+
+// intsFromIterable returns the integers produced by an iterable.
+func intsFromIterable(t *testing.T, it Iterable) []int {
+	t.Helper()
+	var got []int
+	for _, v := range iterableToSlice(t, it) {
+		got = append(got, v.(int))
+	}
+	return got
+}
+
+func TestGraph_Edges(t *testing.T) {
+	for _, s := range graphStorages {
+		t.Run(s.name, func(t *testing.T) {
+			g := newTestGraph(t, 4, s.flags, [][2]int{{0, 1}, {1, 2}, {2, 2}, {1, 2}, {3, 0}})
+			if g.Order() != 4 || g.Size() != 5 || g.Directed() || g.Weighted() {
+				t.Fatalf("Order(): %d, Size(): %d, Directed(): %v, Weighted(): %v, want 4, 5, false, false",
+					g.Order(), g.Size(), g.Directed(), g.Weighted())
+			}
+			want := []interface{}{
+				Edge{From: 0, To: 1, Weight: 1}, Edge{From: 0, To: 3, Weight: 1},
+				Edge{From: 1, To: 2, Weight: 1}, Edge{From: 1, To: 2, Weight: 1}, Edge{From: 2, To: 2, Weight: 1},
+			}
+			if diff := cmp.Diff(want, iterableToSlice(t, g.Edges())); diff != "" {
+				t.Errorf("Edges() produced unwanted edges (-want +got):\n%s", diff)
+			}
+			for v, want := range []int{2, 3, 3, 1} {
+				if got, err := g.Degree(v); err != nil || got != want {
+					t.Errorf("Degree(%d): %d, %v, want %d, nil", v, got, err, want)
+				}
+			}
+			if ok, _ := g.HasEdge(2, 1); !ok {
+				t.Error("HasEdge(2, 1) returned false for an undirected edge")
+			}
+			if err := g.RemoveEdge(2, 1); err != nil {
+				t.Fatalf("RemoveEdge(2, 1) returned unexpected error; %v", err)
+			}
+			if ok, _ := g.HasEdge(1, 2); ok || g.Size() != 3 {
+				t.Errorf("HasEdge(1, 2): %v, Size(): %d after RemoveEdge(), want false, 3", ok, g.Size())
+			}
+			g.RemoveEdge(2, 2)
+			if ok, _ := g.HasEdge(2, 2); ok || g.Size() != 2 {
+				t.Errorf("HasEdge(2, 2): %v, Size(): %d after RemoveEdge(), want false, 2", ok, g.Size())
+			}
+			if v := g.AddVertex(); v != 4 || g.Order() != 5 {
+				t.Errorf("AddVertex(): %d, Order(): %d, want 4, 5", v, g.Order())
+			}
+		})
+	}
+}
+
+func TestGraph_DirectedWeighted(t *testing.T) {
+	g := NewGraph(3, Directed|Weighted)
+	g.AddWeightedEdge(0, 1, -5)
+	g.AddWeightedEdge(1, 0, 7)
+	g.AddWeightedEdge(0, 1, 2)
+	it, _ := g.Neighbors(0)
+	want := []interface{}{Edge{From: 0, To: 1, Weight: -5}, Edge{From: 0, To: 1, Weight: 2}}
+	if diff := cmp.Diff(want, iterableToSlice(t, it)); diff != "" {
+		t.Errorf("Neighbors(0) produced unwanted edges (-want +got):\n%s", diff)
+	}
+	if ok, _ := g.HasEdge(1, 2); ok {
+		t.Error("HasEdge(1, 2) returned true for a missing edge")
+	}
+	g.RemoveEdge(0, 1)
+	if g.Size() != 1 {
+		t.Errorf("Size(): %d after removing parallel edges, want 1", g.Size())
+	}
+	if ok, _ := g.HasEdge(1, 0); !ok {
+		t.Error("RemoveEdge(0, 1) removed the edge in the opposite direction")
+	}
+}
+
+func TestGraph_Errors(t *testing.T) {
+	g := NewGraph(2, 0)
+	if err := g.AddWeightedEdge(0, 1, 3); err == nil {
+		t.Error("AddWeightedEdge() on an unweighted graph returned nil error, want error")
+	}
+	for _, v := range []int{-1, 2} {
+		if err := g.AddEdge(0, v); err == nil {
+			t.Errorf("AddEdge(0, %d) returned nil error, want error", v)
+		}
+		if err := g.RemoveEdge(v, 0); err == nil {
+			t.Errorf("RemoveEdge(%d, 0) returned nil error, want error", v)
+		}
+		if _, err := g.HasEdge(v, 0); err == nil {
+			t.Errorf("HasEdge(%d, 0) returned nil error, want error", v)
+		}
+		if _, err := g.Neighbors(v); err == nil {
+			t.Errorf("Neighbors(%d) returned nil error, want error", v)
+		}
+		if _, err := g.Degree(v); err == nil {
+			t.Errorf("Degree(%d) returned nil error, want error", v)
+		}
+		if _, err := g.BFS(v); err == nil {
+			t.Errorf("BFS(%d) returned nil error, want error", v)
+		}
+		if _, err := g.DFS(v); err == nil {
+			t.Errorf("DFS(%d) returned nil error, want error", v)
+		}
+	}
+}
+
+func TestGraph_Traversals(t *testing.T) {
+	//   0 - 1 - 3
+	//   |   |
+	//   2 - 4   5 - 6
+	edges := [][2]int{{0, 1}, {0, 2}, {1, 3}, {1, 4}, {2, 4}, {5, 6}}
+	for _, s := range graphStorages {
+		t.Run(s.name, func(t *testing.T) {
+			g := newTestGraph(t, 7, s.flags, edges)
+			bfs, _ := g.BFS(0)
+			if diff := cmp.Diff([]int{0, 1, 2, 3, 4}, intsFromIterable(t, bfs.Order())); diff != "" {
+				t.Errorf("BFS(0) visit order mismatch (-want +got):\n%s", diff)
+			}
+			dfs, _ := g.DFS(0)
+			if diff := cmp.Diff([]int{0, 1, 3, 4, 2}, intsFromIterable(t, dfs.Order())); diff != "" {
+				t.Errorf("DFS(0) visit order mismatch (-want +got):\n%s", diff)
+			}
+			forest := g.DFSForest()
+			if diff := cmp.Diff([]int{0, 1, 3, 4, 2, 5, 6}, intsFromIterable(t, forest.Order())); diff != "" {
+				t.Errorf("DFSForest() visit order mismatch (-want +got):\n%s", diff)
+			}
+			for _, test := range []struct {
+				tr       *Traversal
+				v        int
+				wantPath []int
+			}{
+				{tr: bfs, v: 4, wantPath: []int{0, 1, 4}},
+				{tr: dfs, v: 2, wantPath: []int{0, 1, 4, 2}},
+				{tr: forest, v: 6, wantPath: []int{5, 6}},
+				{tr: bfs, v: 0, wantPath: []int{0}},
+			} {
+				path, err := test.tr.PathTo(test.v)
+				if err != nil {
+					t.Fatalf("PathTo(%d) returned unexpected error; %v", test.v, err)
+				}
+				if diff := cmp.Diff(test.wantPath, path); diff != "" {
+					t.Errorf("PathTo(%d) mismatch (-want +got):\n%s", test.v, diff)
+				}
+			}
+			if p, ok := dfs.Parent(2); !ok || p != 4 {
+				t.Errorf("Parent(2): %d, %v, want 4, true", p, ok)
+			}
+			if _, ok := dfs.Parent(0); ok {
+				t.Error("Parent(0) returned true for the root of the search")
+			}
+			if bfs.Visited(5) {
+				t.Error("BFS(0) visited a vertex of another component")
+			}
+			if _, err := bfs.PathTo(5); err == nil {
+				t.Error("PathTo(5) returned nil error for an unreached vertex, want error")
+			}
+		})
+	}
+}
+
+func TestGraph_BFSShortestPaths(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 1 + r.Intn(30)
+		g := randomGraph(r, n, r.Intn(3*n), 1, Directed)
+		// Bellman-Ford style relaxation of unit weights as reference.
+		dist := make([]int, n)
+		for v := range dist {
+			dist[v] = -1
+		}
+		dist[0] = 0
+		for changed := true; changed; {
+			changed = false
+			for it := g.Edges(); it.Scan(); {
+				e := graphNextEdge(it)
+				if dist[e.From] >= 0 && (dist[e.To] < 0 || dist[e.From]+1 < dist[e.To]) {
+					dist[e.To], changed = dist[e.From]+1, true
+				}
+			}
+		}
+		bfs, _ := g.BFS(0)
+		for v := 0; v < n; v++ {
+			path, err := bfs.PathTo(v)
+			if (err == nil) != (dist[v] >= 0) || (err == nil && len(path)-1 != dist[v]) {
+				t.Fatalf("PathTo(%d): %v, %v, want a path of %d edges", v, path, err, dist[v])
+			}
+		}
+	}
+}
+
+func TestGraph_ConnectedComponents(t *testing.T) {
+	g := newTestGraph(t, 7, Directed, [][2]int{{1, 0}, {2, 1}, {3, 4}, {6, 4}})
+	component, count := g.ConnectedComponents()
+	if diff := cmp.Diff([]int{0, 0, 0, 1, 1, 2, 1}, component); diff != "" || count != 3 {
+		t.Errorf("ConnectedComponents(): %d components, labels mismatch (-want +got):\n%s", count, diff)
+	}
+	if component, count := NewGraph(0, 0).ConnectedComponents(); len(component) != 0 || count != 0 {
+		t.Errorf("ConnectedComponents() of an empty graph: %v, %d", component, count)
+	}
+}
+
+// checkCycle verifies that cycle is a cycle of g.
+func checkCycle(t *testing.T, g *Graph, cycle []int) {
+	t.Helper()
+	if len(cycle) == 0 {
+		t.Fatal("found an empty cycle")
+	}
+	seen := map[int]bool{}
+	for i, v := range cycle {
+		if seen[v] {
+			t.Fatalf("cycle %v repeats vertex %d", cycle, v)
+		}
+		seen[v] = true
+		if ok, _ := g.HasEdge(v, cycle[(i+1)%len(cycle)]); !ok {
+			t.Fatalf("cycle %v uses a missing edge from %d", cycle, v)
+		}
+	}
+}
+
+func TestGraph_FindCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags GraphFlags
+		edges [][2]int
+		want  bool
+	}{
+		{name: "empty", flags: 0, want: false},
+		{name: "undirected tree", flags: 0, edges: [][2]int{{0, 1}, {0, 2}, {2, 3}, {2, 4}}, want: false},
+		{name: "undirected triangle", flags: 0, edges: [][2]int{{0, 1}, {1, 2}, {3, 4}, {2, 0}}, want: true},
+		{name: "undirected parallel edges", flags: 0, edges: [][2]int{{0, 1}, {1, 2}, {2, 1}}, want: true},
+		{name: "undirected self-loop", flags: 0, edges: [][2]int{{0, 1}, {3, 3}}, want: true},
+		{name: "directed DAG", flags: Directed, edges: [][2]int{{0, 1}, {0, 2}, {1, 2}, {3, 2}}, want: false},
+		{name: "directed 2-cycle", flags: Directed, edges: [][2]int{{0, 1}, {1, 0}}, want: true},
+		{name: "directed cycle", flags: Directed, edges: [][2]int{{4, 0}, {0, 1}, {1, 2}, {2, 3}, {3, 1}}, want: true},
+		{name: "directed self-loop", flags: Directed, edges: [][2]int{{2, 2}}, want: true},
+	}
+	for _, test := range tests {
+		for _, s := range graphStorages {
+			t.Run(fmt.Sprintf("%s %s", test.name, s.name), func(t *testing.T) {
+				g := newTestGraph(t, 5, test.flags|s.flags, test.edges)
+				cycle, ok := g.FindCycle()
+				if ok != test.want || g.HasCycle() != test.want {
+					t.Fatalf("FindCycle(): %v, %v, want %v", cycle, ok, test.want)
+				}
+				if ok {
+					checkCycle(t, g, cycle)
+				}
+			})
+		}
+	}
+}
+
+func TestGraph_FindCycleRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(12)
+		flags := GraphFlags(0)
+		if r.Intn(2) == 0 {
+			flags = Directed
+		}
+		g := randomGraph(r, n, r.Intn(n+2), 1, flags)
+		// A graph is acyclic iff a search from every vertex never reaches it again (directed), or
+		// it's a forest: every component has one edge less than vertices (undirected).
+		acyclic := true
+		if g.Directed() {
+			for v := 0; v < n && acyclic; v++ {
+				it, _ := g.Neighbors(v)
+				for it.Scan() {
+					bfs, _ := g.BFS(graphNextEdge(it).To)
+					acyclic = acyclic && !bfs.Visited(v)
+				}
+			}
+		} else {
+			_, components := g.ConnectedComponents()
+			acyclic = g.Size() == n-components
+		}
+		cycle, ok := g.FindCycle()
+		if ok == acyclic {
+			t.Fatalf("FindCycle(): %v, %v, want %v", cycle, ok, !acyclic)
+		}
+		if ok {
+			checkCycle(t, g, cycle)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ads
+
+import "fmt"
+
+// Group describes an abelian group: a commutative and associative operation with an identity
+// element and an inverse for every element. Elements are passed around as empty interfaces, each
+// implementation documents the concrete type it operates on.
+type Group interface {
+	// Identity returns the identity element of the group.
+	Identity() interface{}
+	// Op combines two elements of the group.
+	Op(a, b interface{}) interface{}
+	// Inverse returns the element that combined with a yields the identity.
+	Inverse(a interface{}) interface{}
+}
+
+// Int64Group is the group of int64 values under addition.
+type Int64Group struct{}
+
+// Identity returns 0.
+func (Int64Group) Identity() interface{} {
+	return int64(0)
+}
+
+// Op returns a + b.
+func (Int64Group) Op(a, b interface{}) interface{} {
+	return a.(int64) + b.(int64)
+}
+
+// Inverse returns -a.
+func (Int64Group) Inverse(a interface{}) interface{} {
+	return -a.(int64)
+}
+
+// KahanSum is a float64 sum that carries the rounding error lost by floating point additions.
+type KahanSum struct {
+	Sum, Compensation float64
+}
+
+// Float64 returns the compensated value of the sum.
+func (k KahanSum) Float64() float64 {
+	return k.Sum + k.Compensation
+}
+
+// KahanGroup is the group of KahanSum values under compensated addition. See Neumaier's
+// improvement of the Kahan summation algorithm for reference
+// https://en.wikipedia.org/wiki/Kahan_summation_algorithm#Further_enhancements.
+type KahanGroup struct{}
+
+// Identity returns a zero sum.
+func (KahanGroup) Identity() interface{} {
+	return KahanSum{}
+}
+
+// Op returns a + b, accumulating the rounding error of the addition into the compensation.
+func (KahanGroup) Op(a, b interface{}) interface{} {
+	x, y := a.(KahanSum), b.(KahanSum)
+	s := x.Sum + y.Sum
+	// TwoSum: recover the exact rounding error of s.
+	yv := s - x.Sum
+	err := (x.Sum - (s - yv)) + (y.Sum - yv)
+	return KahanSum{Sum: s, Compensation: x.Compensation + y.Compensation + err}
+}
+
+// Inverse returns -a.
+func (KahanGroup) Inverse(a interface{}) interface{} {
+	x := a.(KahanSum)
+	return KahanSum{Sum: -x.Sum, Compensation: -x.Compensation}
+}
+
+// ModularGroup is the group of int64 values in [0, modulus) under addition modulo modulus.
+type ModularGroup struct {
+	modulus int64
+}
+
+// NewModularGroup returns the additive group of integers modulo m. m must be positive.
+func NewModularGroup(m int64) (*ModularGroup, error) {
+	if m <= 0 {
+		return nil, fmt.Errorf("modulus must be positive, got %d", m)
+	}
+	return &ModularGroup{modulus: m}, nil
+}
+
+// Modulus returns the modulus of the group.
+func (g *ModularGroup) Modulus() int64 {
+	return g.modulus
+}
+
+// Reduce maps any int64 into the [0, modulus) range.
+func (g *ModularGroup) Reduce(a int64) int64 {
+	a %= g.modulus
+	if a < 0 {
+		a += g.modulus
+	}
+	return a
+}
+
+// Identity returns 0.
+func (g *ModularGroup) Identity() interface{} {
+	return int64(0)
+}
+
+// Op returns (a + b) mod modulus without overflowing. Elements out of [0, modulus) are reduced
+// first, so values passed by callers don't need to be.
+func (g *ModularGroup) Op(a, b interface{}) interface{} {
+	x, y := a.(int64), b.(int64)
+	if x < 0 || x >= g.modulus {
+		x = g.Reduce(x)
+	}
+	if y < 0 || y >= g.modulus {
+		y = g.Reduce(y)
+	}
+	if x >= g.modulus-y {
+		return x - (g.modulus - y)
+	}
+	return x + y
+}
+
+// Inverse returns -a mod modulus.
+func (g *ModularGroup) Inverse(a interface{}) interface{} {
+	return g.Reduce(-a.(int64))
+}
+
+// XORGroup is the group of uint64 values under bitwise exclusive or; every element is its own
+// inverse.
+type XORGroup struct{}
+
+// Identity returns 0.
+func (XORGroup) Identity() interface{} {
+	return uint64(0)
+}
+
+// Op returns a ^ b.
+func (XORGroup) Op(a, b interface{}) interface{} {
+	return a.(uint64) ^ b.(uint64)
+}
+
+// Inverse returns a.
+func (XORGroup) Inverse(a interface{}) interface{} {
+	return a.(uint64)
+}
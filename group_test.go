@@ -0,0 +1,102 @@
+package ads
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func logGroupSatisfaction(t *testing.T, ds string, g Group) {
+	t.Helper()
+	t.Logf("%s satisfies Group interface: %v", ds, g)
+}
+
+// TestGroupInterfaceSatisfaction verifies (during compilation) that the
+// multiple group implementations satisfy the Group interface.
+func TestGroupInterfaceSatisfaction(t *testing.T) {
+	var g Group
+
+	g = Int64Group{}
+	logGroupSatisfaction(t, "Int64Group", g)
+	g = KahanGroup{}
+	logGroupSatisfaction(t, "KahanGroup", g)
+	g, _ = NewModularGroup(7)
+	logGroupSatisfaction(t, "ModularGroup", g)
+	g = XORGroup{}
+	logGroupSatisfaction(t, "XORGroup", g)
+}
+
+// checkGroupAxioms verifies identity, inverse, commutativity and associativity over the given
+// elements using eq to compare them.
+func checkGroupAxioms(t *testing.T, g Group, elems []interface{}, eq func(a, b interface{}) bool) {
+	t.Helper()
+	e := g.Identity()
+	for _, a := range elems {
+		if !eq(g.Op(a, e), a) {
+			t.Errorf("Op(%v, identity): %v, want %v", a, g.Op(a, e), a)
+		}
+		if !eq(g.Op(a, g.Inverse(a)), e) {
+			t.Errorf("Op(%v, Inverse(%v)): %v, want identity", a, a, g.Op(a, g.Inverse(a)))
+		}
+		for _, b := range elems {
+			if !eq(g.Op(a, b), g.Op(b, a)) {
+				t.Errorf("Op(%v, %v) != Op(%v, %v)", a, b, b, a)
+			}
+			for _, c := range elems {
+				if !eq(g.Op(g.Op(a, b), c), g.Op(a, g.Op(b, c))) {
+					t.Errorf("Op is not associative for %v, %v, %v", a, b, c)
+				}
+			}
+		}
+	}
+}
+
+func TestGroup_Axioms(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	equal := func(a, b interface{}) bool { return a == b }
+
+	t.Run("int64", func(t *testing.T) {
+		elems := []interface{}{int64(0), int64(1), int64(-5), int64(1 << 40)}
+		checkGroupAxioms(t, Int64Group{}, elems, equal)
+	})
+
+	t.Run("kahan", func(t *testing.T) {
+		elems := []interface{}{KahanSum{}, KahanSum{Sum: 0.1}, KahanSum{Sum: 1e16}, KahanSum{Sum: -3.5}}
+		checkGroupAxioms(t, KahanGroup{}, elems, func(a, b interface{}) bool {
+			return a.(KahanSum).Float64() == b.(KahanSum).Float64()
+		})
+	})
+
+	t.Run("modular", func(t *testing.T) {
+		for _, m := range []int64{1, 2, 7, 1000000007, math.MaxInt64} {
+			g, err := NewModularGroup(m)
+			if err != nil {
+				t.Fatalf("NewModularGroup(%d) returned unexpected error; %v", m, err)
+			}
+			elems := []interface{}{int64(0), g.Reduce(-1), g.Reduce(m / 2), g.Reduce(r.Int63())}
+			checkGroupAxioms(t, g, elems, equal)
+		}
+	})
+
+	t.Run("xor", func(t *testing.T) {
+		elems := []interface{}{uint64(0), uint64(1), r.Uint64(), r.Uint64()}
+		checkGroupAxioms(t, XORGroup{}, elems, equal)
+	})
+}
+
+func TestNewModularGroup(t *testing.T) {
+	for _, m := range []int64{0, -1} {
+		if _, err := NewModularGroup(m); err == nil {
+			t.Errorf("NewModularGroup(%d) returned nil error, want error", m)
+		}
+	}
+	g, _ := NewModularGroup(7)
+	for a, want := range map[int64]int64{0: 0, 6: 6, 7: 0, 15: 1, -1: 6, -15: 6} {
+		if got := g.Reduce(a); got != want {
+			t.Errorf("Reduce(%d): %d, want %d", a, got, want)
+		}
+	}
+	if g.Modulus() != 7 {
+		t.Errorf("Modulus(): %d, want 7", g.Modulus())
+	}
+}
@@ -14,6 +14,8 @@ type HashTable struct {
 	capacity int
 	// length is the number of used buckets
 	length int
+	// deleted is the number of buckets flagged as deleted
+	deleted int
 }
 
 // hasTableBucket stores the key/value pair and a deleted flag.
@@ -33,6 +35,7 @@ func (h *HashTable) init() *HashTable {
 	h.data = make([]*hashTableBucket, hashTableInitialSize)
 	h.capacity = hashTableInitialSize
 	h.length = 0
+	h.deleted = 0
 	return h
 }
 
@@ -42,15 +45,27 @@ func (h *HashTable) initLazy() {
 	}
 }
 
-// hash returns the integer hash of a given string using Polynomial Rolling Hash algorithm.
-func (h *HashTable) hash(k string) int {
+// PolynomialHash returns the Polynomial Rolling Hash of the runes of s modulo m, that is
+// s[0]*p^(n-1) + s[1]*p^(n-2) + ... + s[n-1] mod m with p = 53. m must be positive and small
+// enough for m*p plus a rune not to overflow.
+func PolynomialHash(s string, m int) int {
 	hash := 0
-	for _, c := range k {
-		hash = (hash*hashTableFMultiplier + int(c)) % h.capacity
+	for _, c := range s {
+		hash = polynomialHashAppend(hash, int(c), m)
 	}
 	return hash
 }
 
+// polynomialHashAppend returns the hash modulo m of a sequence whose hash is h once c is appended.
+func polynomialHashAppend(h, c, m int) int {
+	return (h*hashTableFMultiplier + c) % m
+}
+
+// hash returns the integer hash of a given string using Polynomial Rolling Hash algorithm.
+func (h *HashTable) hash(k string) int {
+	return PolynomialHash(k, h.capacity)
+}
+
 // probe computes the linear probing sequence for a hashed number k at the i-th location.
 func (h *HashTable) probe(k, i int) int {
 	return (k + i) % h.capacity
@@ -73,7 +88,8 @@ func (h *HashTable) Set(k string, v interface{}) {
 	h.initLazy()
 	// Maximum load is based in CPython's USABLE_FRACTION
 	// https://github.com/python/cpython/blob/master/Objects/dictobject.c#L412
-	if h.length >= (h.capacity<<1)/2 {
+	// Deleted buckets are counted too, so there's always a NIL bucket ending the probing sequences.
+	if h.length+h.deleted >= (h.capacity<<1)/3 {
 		h.resize()
 	}
 	hash := h.hash(k)
@@ -97,6 +113,7 @@ func (h *HashTable) Set(k string, v interface{}) {
 		h.data[deleted].value = v
 		h.data[deleted].deleted = false
 		h.length++
+		h.deleted--
 	default: // Bucket is NIL
 		h.data[j] = &hashTableBucket{key: k, value: v, deleted: false}
 		h.length++
@@ -108,7 +125,11 @@ func (h *HashTable) resize() {
 	// New capacity is based in CPython's 3.4.0-3.6.0 GROWTH_RATE
 	// https://github.com/python/cpython/blob/master/Objects/dictobject.c#L427
 	h.capacity = (h.length * 2) + (h.capacity / 2)
+	if h.capacity < hashTableInitialSize { // Tables with many deleted buckets shrink
+		h.capacity = hashTableInitialSize
+	}
 	h.length = 0
+	h.deleted = 0
 	tmp := h.data
 	h.data = make([]*hashTableBucket, h.capacity)
 	for _, kv := range tmp {
@@ -127,6 +148,7 @@ func (h *HashTable) Remove(k string) {
 			h.data[j].deleted = true
 			h.data[j].value = nil // free reference to removed value
 			h.length--
+			h.deleted++
 			return
 		}
 	}
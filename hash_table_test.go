@@ -288,3 +288,49 @@ func TestHashTable_LargeDataSets(t *testing.T) {
 		})
 	}
 }
+
+func TestHashTable_MissingKeysAfterChurn(t *testing.T) {
+	// Every probing sequence must end in a NIL bucket, even when the table is crowded with live
+	// and deleted buckets.
+	table := NewHashTable()
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("k%d", i)
+		table.Set(key, i)
+		if i%3 != 0 {
+			table.Remove(key)
+		}
+		if _, ok := table.Get(fmt.Sprintf("missing%d", i)); ok {
+			t.Fatalf("table.Get(missing%d) found a missing key", i)
+		}
+		table.Remove(fmt.Sprintf("missing%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		v, ok := table.Get(fmt.Sprintf("k%d", i))
+		if want := i%3 == 0; ok != want || (ok && v != i) {
+			t.Fatalf("table.Get(k%d): %v, %v, want %d, %v", i, v, ok, i, want)
+		}
+	}
+	if table.Size() != 334 {
+		t.Errorf("table.Size(): %d, want 334", table.Size())
+	}
+}
+
+func TestPolynomialHash(t *testing.T) {
+	tests := []struct {
+		s    string
+		m    int
+		want int
+	}{
+		{s: "", m: 7, want: 0},
+		{s: "a", m: 1000, want: 97},
+		{s: "ab", m: 1000, want: (97*53 + 98) % 1000},
+		// Runes are hashed whole, not by their UTF-8 bytes.
+		{s: "é", m: 1000, want: 233},
+		{s: "ab", m: 1, want: 0},
+	}
+	for _, test := range tests {
+		if got := PolynomialHash(test.s, test.m); got != test.want {
+			t.Errorf("PolynomialHash(%q, %d): %d, want %d", test.s, test.m, got, test.want)
+		}
+	}
+}
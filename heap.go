@@ -0,0 +1,202 @@
+package ads
+
+import "fmt"
+
+// Heap interface describes a min-priority queue with support for decreasing keys and melding.
+type Heap interface {
+	// Insert a new value with the given key, returns a handle to the stored item.
+	Insert(key int, v interface{}) *HeapItem
+	// FindMin returns the item with the smallest key without removing it.
+	FindMin() (*HeapItem, error)
+	// DeleteMin removes and returns the item with the smallest key.
+	DeleteMin() (*HeapItem, error)
+	// DecreaseKey lowers the key of an item stored in the heap. The item must have been inserted
+	// into this heap (or into a heap merged into it).
+	DecreaseKey(item *HeapItem, key int) error
+	// Merge moves all the items of the given heap into this one, leaving the other heap empty.
+	Merge(Heap) error
+	// Size returns the number of elements stored in the heap.
+	Size() int
+	// Empty removes all elements from the heap.
+	Empty()
+}
+
+// HeapItem holds a value and its priority key. Items are returned by Insert and work as handles
+// for DecreaseKey.
+type HeapItem struct {
+	Key   int
+	Value interface{}
+	// index is the position of the item in array-based heaps.
+	index int
+	// node is the underlying node of the item in linked heaps, nil if the item is not stored.
+	node interface{}
+}
+
+// String returns the item string representation
+func (i *HeapItem) String() string {
+	return fmt.Sprintf("%d:%v", i.Key, i.Value)
+}
+
+// validateDecreaseKey returns an error if item can't take the new key.
+func validateDecreaseKey(item *HeapItem, key int) error {
+	if item == nil {
+		return fmt.Errorf("nil heap item")
+	}
+	if key > item.Key {
+		return fmt.Errorf("new key %d is greater than current key %d", key, item.Key)
+	}
+	return nil
+}
+
+// heapOwner identifies the linked heap a node belongs to. Merging a heap points its owner to the
+// owner of the heap it's merged into, as in a disjoint set, so nodes aren't updated one by one.
+type heapOwner struct {
+	parent *heapOwner
+}
+
+// find returns the owner of the heap currently holding nodes owned by o, compressing the path.
+func (o *heapOwner) find() *heapOwner {
+	root := o
+	for root.parent != nil {
+		root = root.parent
+	}
+	for o != root {
+		next := o.parent
+		o.parent = root
+		o = next
+	}
+	return root
+}
+
+// DaryHeap is a Heap stored in a slice where each node has (at most) d children.
+type DaryHeap struct {
+	d    int
+	data []*HeapItem
+}
+
+// NewDaryHeap returns an empty heap where each node has at most d children. d must be at least 2.
+func NewDaryHeap(d int) (*DaryHeap, error) {
+	if d < 2 {
+		return nil, fmt.Errorf("heap arity must be at least 2, got %d", d)
+	}
+	return &DaryHeap{d: d}, nil
+}
+
+func (h *DaryHeap) parent(i int) int {
+	return (i - 1) / h.d
+}
+
+func (h *DaryHeap) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.data[i].index = i
+	h.data[j].index = j
+}
+
+// siftUp moves the item at i towards the root until the heap property is restored.
+func (h *DaryHeap) siftUp(i int) {
+	for i > 0 {
+		p := h.parent(i)
+		if h.data[p].Key <= h.data[i].Key {
+			return
+		}
+		h.swap(i, p)
+		i = p
+	}
+}
+
+// siftDown moves the item at i towards the leaves until the heap property is restored.
+func (h *DaryHeap) siftDown(i int) {
+	n := len(h.data)
+	for {
+		min := i
+		for c := h.d*i + 1; c <= h.d*i+h.d && c < n; c++ {
+			if h.data[c].Key < h.data[min].Key {
+				min = c
+			}
+		}
+		if min == i {
+			return
+		}
+		h.swap(i, min)
+		i = min
+	}
+}
+
+// Insert a new value with the given key, returns a handle to the stored item.
+func (h *DaryHeap) Insert(key int, v interface{}) *HeapItem {
+	item := &HeapItem{Key: key, Value: v, index: len(h.data)}
+	h.data = append(h.data, item)
+	h.siftUp(item.index)
+	return item
+}
+
+// FindMin returns the item with the smallest key without removing it.
+func (h *DaryHeap) FindMin() (*HeapItem, error) {
+	if len(h.data) == 0 {
+		return nil, fmt.Errorf("empty heap")
+	}
+	return h.data[0], nil
+}
+
+// DeleteMin removes and returns the item with the smallest key.
+func (h *DaryHeap) DeleteMin() (*HeapItem, error) {
+	if len(h.data) == 0 {
+		return nil, fmt.Errorf("empty heap")
+	}
+	last := len(h.data) - 1
+	h.swap(0, last)
+	item := h.data[last]
+	// Avoid memory leaks (free references for garbage collector)
+	h.data[last] = nil
+	h.data = h.data[:last]
+	h.siftDown(0)
+	item.index = -1
+	return item, nil
+}
+
+// DecreaseKey lowers the key of an item stored in the heap.
+func (h *DaryHeap) DecreaseKey(item *HeapItem, key int) error {
+	if err := validateDecreaseKey(item, key); err != nil {
+		return err
+	}
+	if item.index < 0 || item.index >= len(h.data) || h.data[item.index] != item {
+		return fmt.Errorf("item %v is not stored in the heap", item)
+	}
+	item.Key = key
+	h.siftUp(item.index)
+	return nil
+}
+
+// Merge moves all the items of the given heap into this one, leaving the other heap empty.
+// The combined data is re-heapified in linear time.
+func (h *DaryHeap) Merge(other Heap) error {
+	o, ok := other.(*DaryHeap)
+	if !ok {
+		return fmt.Errorf("cannot merge %T into %T", other, h)
+	}
+	if o == h {
+		return nil
+	}
+	for _, item := range o.data {
+		item.index = len(h.data)
+		h.data = append(h.data, item)
+	}
+	o.data = nil
+	for i := h.parent(len(h.data) - 1); i >= 0; i-- {
+		h.siftDown(i)
+	}
+	return nil
+}
+
+// Size returns the number of elements stored in the heap.
+func (h *DaryHeap) Size() int {
+	return len(h.data)
+}
+
+// Empty removes all elements from the heap.
+func (h *DaryHeap) Empty() {
+	for _, item := range h.data {
+		item.index = -1
+	}
+	h.data = nil
+}
@@ -0,0 +1,323 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func logHeapSatisfaction(t *testing.T, ds string, h Heap) {
+	t.Helper()
+	t.Logf("%s satisfies Heap interface: %v", ds, h)
+}
+
+// TestHeapInterfaceSatisfaction verifies (during compilation) that the
+// multiple heap implementations satisfy the Heap interface.
+func TestHeapInterfaceSatisfaction(t *testing.T) {
+	var h Heap
+
+	h, _ = NewDaryHeap(2)
+	logHeapSatisfaction(t, "DaryHeap", h)
+	h = NewPairingHeap()
+	logHeapSatisfaction(t, "PairingHeap", h)
+	h = NewBinomialHeap()
+	logHeapSatisfaction(t, "BinomialHeap", h)
+	h = NewFibonacciHeap()
+	logHeapSatisfaction(t, "FibonacciHeap", h)
+}
+
+// heapFactories lists every Heap implementation checked by the conformance suite.
+var heapFactories = []struct {
+	name string
+	new  func() Heap
+}{
+	{name: "binary", new: func() Heap { h, _ := NewDaryHeap(2); return h }},
+	{name: "4-ary", new: func() Heap { h, _ := NewDaryHeap(4); return h }},
+	{name: "pairing", new: func() Heap { return NewPairingHeap() }},
+	{name: "binomial", new: func() Heap { return NewBinomialHeap() }},
+	{name: "fibonacci", new: func() Heap { return NewFibonacciHeap() }},
+}
+
+func TestNewDaryHeap_Errors(t *testing.T) {
+	for _, d := range []int{-1, 0, 1} {
+		if _, err := NewDaryHeap(d); err == nil {
+			t.Errorf("NewDaryHeap(%d) returned nil error, want error", d)
+		}
+	}
+}
+
+// drainHeap deletes every element of the heap and returns the keys in extraction order.
+func drainHeap(t *testing.T, h Heap) []int {
+	t.Helper()
+	keys := make([]int, 0, h.Size())
+	for h.Size() > 0 {
+		min, err := h.FindMin()
+		if err != nil {
+			t.Fatalf("FindMin() returned unexpected error; %v", err)
+		}
+		item, err := h.DeleteMin()
+		if err != nil {
+			t.Fatalf("DeleteMin() returned unexpected error; %v", err)
+		}
+		if item != min {
+			t.Fatalf("DeleteMin(): %v != FindMin(): %v", item, min)
+		}
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+func TestHeap_Conformance(t *testing.T) {
+	for _, f := range heapFactories {
+		t.Run(f.name, func(t *testing.T) {
+			t.Run("errors on empty heap", func(t *testing.T) {
+				h := f.new()
+				if _, err := h.FindMin(); err == nil {
+					t.Error("FindMin() returned nil error, want error")
+				}
+				if _, err := h.DeleteMin(); err == nil {
+					t.Error("DeleteMin() returned nil error, want error")
+				}
+			})
+
+			t.Run("sorted extraction", func(t *testing.T) {
+				for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+					h := f.new()
+					r := rand.New(rand.NewSource(int64(n)))
+					want := make([]int, n)
+					for i := range want {
+						want[i] = r.Intn(n + 1)
+						h.Insert(want[i], i)
+					}
+					if h.Size() != n {
+						t.Fatalf("Size(): %d, want %d", h.Size(), n)
+					}
+					sort.Ints(want)
+					if diff := cmp.Diff(want, drainHeap(t, h)); diff != "" {
+						t.Errorf("n = %d: extraction order mismatch (-want +got):\n%s", n, diff)
+					}
+				}
+			})
+
+			t.Run("decrease key", func(t *testing.T) {
+				h := f.new()
+				r := rand.New(rand.NewSource(42))
+				items := make([]*HeapItem, 500)
+				for i := range items {
+					items[i] = h.Insert(r.Intn(10000), i)
+				}
+				// Extract some items first so the remaining ones are spread across the structure.
+				for i := 0; i < 50; i++ {
+					if _, err := h.DeleteMin(); err != nil {
+						t.Fatalf("DeleteMin() returned unexpected error; %v", err)
+					}
+				}
+				var want []int
+				for _, item := range items {
+					if h.DecreaseKey(item, item.Key) != nil {
+						continue // already extracted
+					}
+					key := item.Key - r.Intn(10000)
+					if err := h.DecreaseKey(item, key); err != nil {
+						t.Fatalf("DecreaseKey(%v, %d) returned unexpected error; %v", item, key, err)
+					}
+					want = append(want, key)
+				}
+				sort.Ints(want)
+				if diff := cmp.Diff(want, drainHeap(t, h)); diff != "" {
+					t.Errorf("extraction order mismatch (-want +got):\n%s", diff)
+				}
+			})
+
+			t.Run("decrease key errors", func(t *testing.T) {
+				h := f.new()
+				item := h.Insert(10, nil)
+				if err := h.DecreaseKey(item, 11); err == nil {
+					t.Error("DecreaseKey() with greater key returned nil error, want error")
+				}
+				if err := h.DecreaseKey(nil, 1); err == nil {
+					t.Error("DecreaseKey() with nil item returned nil error, want error")
+				}
+				if _, err := h.DeleteMin(); err != nil {
+					t.Fatalf("DeleteMin() returned unexpected error; %v", err)
+				}
+				if err := h.DecreaseKey(item, 1); err == nil {
+					t.Error("DecreaseKey() on removed item returned nil error, want error")
+				}
+				item = h.Insert(10, nil)
+				h.Empty()
+				if err := h.DecreaseKey(item, 1); err == nil {
+					t.Error("DecreaseKey() after Empty() returned nil error, want error")
+				}
+				if h.Size() != 0 {
+					t.Errorf("Size(): %d after calling Empty()", h.Size())
+				}
+			})
+
+			t.Run("decrease key of another heap's item", func(t *testing.T) {
+				a, b := f.new(), f.new()
+				a.Insert(5, nil)
+				item := b.Insert(10, nil)
+				b.Insert(20, nil)
+				if err := a.DecreaseKey(item, 1); err == nil {
+					t.Error("DecreaseKey() with another heap's item returned nil error, want error")
+				}
+				if item.Key != 10 {
+					t.Errorf("item key: %d after rejected DecreaseKey(), want 10", item.Key)
+				}
+				// After merging, the item belongs to the receiving heap only.
+				c := f.new()
+				c.Insert(0, nil)
+				if err := c.Merge(b); err != nil {
+					t.Fatalf("Merge() returned unexpected error; %v", err)
+				}
+				if err := b.DecreaseKey(item, 1); err == nil {
+					t.Error("DecreaseKey() on merged heap returned nil error, want error")
+				}
+				if err := a.Merge(c); err != nil {
+					t.Fatalf("Merge() returned unexpected error; %v", err)
+				}
+				if err := c.DecreaseKey(item, 1); err == nil {
+					t.Error("DecreaseKey() on merged heap returned nil error, want error")
+				}
+				if err := a.DecreaseKey(item, 1); err != nil {
+					t.Fatalf("DecreaseKey() on merged item returned unexpected error; %v", err)
+				}
+				if diff := cmp.Diff([]int{0, 1, 5, 20}, drainHeap(t, a)); diff != "" {
+					t.Errorf("extraction order mismatch (-want +got):\n%s", diff)
+				}
+				if b.Size() != 0 || c.Size() != 0 {
+					t.Errorf("Size(): %d and %d on merged heaps, want 0", b.Size(), c.Size())
+				}
+			})
+
+			t.Run("merge", func(t *testing.T) {
+				a, b := f.new(), f.new()
+				var want []int
+				var bItems []*HeapItem
+				for i := 0; i < 100; i++ {
+					a.Insert(i*2, nil)
+					bItems = append(bItems, b.Insert(i*2+1, nil))
+				}
+				if err := a.Merge(b); err != nil {
+					t.Fatalf("Merge() returned unexpected error; %v", err)
+				}
+				if b.Size() != 0 {
+					t.Errorf("Size(): %d on merged heap, want 0", b.Size())
+				}
+				// Handles from the merged heap remain valid.
+				if err := a.DecreaseKey(bItems[99], -1); err != nil {
+					t.Fatalf("DecreaseKey() on merged item returned unexpected error; %v", err)
+				}
+				want = append(want, -1)
+				for i := 0; i < 199; i++ {
+					want = append(want, i)
+				}
+				if diff := cmp.Diff(want, drainHeap(t, a)); diff != "" {
+					t.Errorf("extraction order mismatch (-want +got):\n%s", diff)
+				}
+				if err := a.Merge(f.new()); err != nil {
+					t.Errorf("Merge() of empty heap returned unexpected error; %v", err)
+				}
+			})
+
+			t.Run("merge different implementations", func(t *testing.T) {
+				h := f.new()
+				for _, o := range heapFactories {
+					other := o.new()
+					if fmt.Sprintf("%T", other) == fmt.Sprintf("%T", h) {
+						continue
+					}
+					if err := h.Merge(other); err == nil {
+						t.Errorf("Merge(%T) returned nil error, want error", other)
+					}
+				}
+			})
+
+			t.Run("randomized against reference", func(t *testing.T) {
+				h := f.new()
+				r := rand.New(rand.NewSource(7))
+				live := map[*HeapItem]bool{}
+				for i := 0; i < 5000; i++ {
+					switch op := r.Intn(10); {
+					case op < 5:
+						live[h.Insert(r.Intn(1000), i)] = true
+					case op < 8 && len(live) > 0:
+						for item := range live {
+							if err := h.DecreaseKey(item, item.Key-r.Intn(100)); err != nil {
+								t.Fatalf("DecreaseKey() returned unexpected error; %v", err)
+							}
+							break
+						}
+					case len(live) > 0:
+						item, err := h.DeleteMin()
+						if err != nil {
+							t.Fatalf("DeleteMin() returned unexpected error; %v", err)
+						}
+						for other := range live {
+							if other.Key < item.Key {
+								t.Fatalf("DeleteMin(): %v, but %v is still stored", item, other)
+							}
+						}
+						delete(live, item)
+					}
+					if h.Size() != len(live) {
+						t.Fatalf("Size(): %d, want %d", h.Size(), len(live))
+					}
+				}
+			})
+		})
+	}
+}
+
+// benchmarkGraph is a random sparse directed graph stored as adjacency lists of (node, weight).
+type benchmarkGraph [][][2]int
+
+func newBenchmarkGraph(n, degree int) benchmarkGraph {
+	r := rand.New(rand.NewSource(1))
+	g := make(benchmarkGraph, n)
+	for u := range g {
+		for i := 0; i < degree; i++ {
+			g[u] = append(g[u], [2]int{r.Intn(n), r.Intn(1000) + 1})
+		}
+	}
+	return g
+}
+
+// heapDijkstra computes single-source shortest distances using decrease-key on the given heap.
+func heapDijkstra(h Heap, g benchmarkGraph) {
+	items := make([]*HeapItem, len(g))
+	done := make([]bool, len(g))
+	items[0] = h.Insert(0, 0)
+	for h.Size() > 0 {
+		item, _ := h.DeleteMin()
+		u := item.Value.(int)
+		done[u] = true
+		for _, e := range g[u] {
+			v, d := e[0], item.Key+e[1]
+			switch {
+			case done[v]:
+			case items[v] == nil:
+				items[v] = h.Insert(d, v)
+			case d < items[v].Key:
+				h.DecreaseKey(items[v], d)
+			}
+		}
+	}
+}
+
+func BenchmarkHeap_Dijkstra(b *testing.B) {
+	for _, size := range []struct{ n, degree int }{{1000, 8}, {10000, 8}, {10000, 64}} {
+		g := newBenchmarkGraph(size.n, size.degree)
+		for _, f := range heapFactories {
+			b.Run(fmt.Sprintf("%s/n=%d/deg=%d", f.name, size.n, size.degree), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					heapDijkstra(f.new(), g)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package ads
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// hyperLogLogMinPrecision and hyperLogLogMaxPrecision bound the number of index bits.
+	hyperLogLogMinPrecision = 4
+	hyperLogLogMaxPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct keys in a stream using 2^p small registers. Each
+// key is hashed to a register, which keeps the longest run of leading zeros seen in the rest of
+// the hashes. The relative standard error is about 1.04/√(2^p).
+//
+// Small cardinalities use a sparse representation holding only the non-zero registers, which
+// switches to the dense array of registers once it would take more space.
+type HyperLogLog struct {
+	p int
+	// sparse holds index<<8 | value for each non-zero register, sorted by index. It's nil once the
+	// sketch is dense.
+	sparse []uint32
+	dense  []uint8
+}
+
+// NewHyperLogLog returns an empty sketch with 2^precision registers. The precision must be in
+// [4, 18].
+func NewHyperLogLog(precision int) (*HyperLogLog, error) {
+	if precision < hyperLogLogMinPrecision || precision > hyperLogLogMaxPrecision {
+		return nil, fmt.Errorf("precision must be in [%d, %d], got %d",
+			hyperLogLogMinPrecision, hyperLogLogMaxPrecision, precision)
+	}
+	return &HyperLogLog{p: precision, sparse: []uint32{}}, nil
+}
+
+// hyperLogLogHash returns a 64-bit hash of key with well mixed bits: FNV-1a followed by the
+// MurmurHash3 finalizer, since HyperLogLog relies on every bit being uniform.
+func hyperLogLogHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return fmix64(h.Sum64())
+}
+
+// fmix64 is the MurmurHash3 finalizer, which makes every bit of x affect every bit of the result.
+func fmix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// isSparse returns whether the sketch uses the sparse representation.
+func (h *HyperLogLog) isSparse() bool {
+	return h.sparse != nil
+}
+
+// Add counts key in the sketch.
+func (h *HyperLogLog) Add(key string) {
+	x := hyperLogLogHash(key)
+	idx := int(x >> uint(64-h.p))
+	// The bit below the remaining 64-p bits caps the run at 64-p+1.
+	rho := uint8(bits.LeadingZeros64(x<<uint(h.p)|1<<uint(h.p-1)) + 1)
+	h.update(idx, rho)
+}
+
+// update raises register idx to rho.
+func (h *HyperLogLog) update(idx int, rho uint8) {
+	if !h.isSparse() {
+		if rho > h.dense[idx] {
+			h.dense[idx] = rho
+		}
+		return
+	}
+	i := sort.Search(len(h.sparse), func(i int) bool { return int(h.sparse[i]>>8) >= idx })
+	if i < len(h.sparse) && int(h.sparse[i]>>8) == idx {
+		if rho > uint8(h.sparse[i]) {
+			h.sparse[i] = uint32(idx)<<8 | uint32(rho)
+		}
+		return
+	}
+	h.sparse = append(h.sparse, 0)
+	copy(h.sparse[i+1:], h.sparse[i:])
+	h.sparse[i] = uint32(idx)<<8 | uint32(rho)
+	// Sparse entries take 4 bytes against 1 byte per dense register.
+	if 4*len(h.sparse) > 1<<uint(h.p) {
+		h.densify()
+	}
+}
+
+// densify switches the sketch to the dense representation.
+func (h *HyperLogLog) densify() {
+	h.dense = make([]uint8, 1<<uint(h.p))
+	for _, e := range h.sparse {
+		h.dense[e>>8] = uint8(e)
+	}
+	h.sparse = nil
+}
+
+// Count returns the estimated number of distinct keys added. Small estimates are corrected with
+// linear counting over the empty registers.
+func (h *HyperLogLog) Count() int {
+	m := 1 << uint(h.p)
+	// sum is the harmonic sum of 2^-register, where empty registers add 1 each.
+	var sum float64
+	zeros := 0
+	if h.isSparse() {
+		zeros = m - len(h.sparse)
+		sum = float64(zeros)
+		for _, e := range h.sparse {
+			sum += math.Ldexp(1, -int(uint8(e)))
+		}
+	} else {
+		for _, r := range h.dense {
+			if r == 0 {
+				zeros++
+			}
+			sum += math.Ldexp(1, -int(r))
+		}
+	}
+	var alpha float64
+	switch m {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/float64(m))
+	}
+	estimate := alpha * float64(m) * float64(m) / sum
+	if estimate <= 2.5*float64(m) && zeros > 0 {
+		estimate = float64(m) * math.Log(float64(m)/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// Merge adds every key counted by another sketch of the same precision to this one, so the
+// result estimates the cardinality of the union of both streams.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.p != other.p {
+		return fmt.Errorf("sketches differ: precision %d, other has precision %d", h.p, other.p)
+	}
+	if other.isSparse() {
+		for _, e := range other.sparse {
+			h.update(int(e>>8), uint8(e))
+		}
+		return nil
+	}
+	if h.isSparse() {
+		h.densify()
+	}
+	for i, r := range other.dense {
+		if r > h.dense[i] {
+			h.dense[i] = r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package ads
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, p := range []int{4, 10, 14} {
+		h, err := NewHyperLogLog(p)
+		if err != nil {
+			t.Fatalf("NewHyperLogLog(%d) returned unexpected error; %v", p, err)
+		}
+		if h.Count() != 0 {
+			t.Errorf("p=%d: Count() of an empty sketch: %d", p, h.Count())
+		}
+		// Allow three standard errors.
+		tolerance := 3 * 1.04 / math.Sqrt(float64(int(1)<<uint(p)))
+		added := 0
+		for _, n := range []int{10, 100, 1000, 10000, 200000} {
+			for ; added < n; added++ {
+				key := fmt.Sprintf("key-%d", added)
+				h.Add(key)
+				h.Add(key) // Duplicates don't count.
+			}
+			if got := h.Count(); math.Abs(float64(got-n)) > tolerance*float64(n) {
+				t.Errorf("p=%d: Count() after %d keys: %d", p, n, got)
+			}
+		}
+	}
+	for _, p := range []int{3, 19} {
+		if _, err := NewHyperLogLog(p); err == nil {
+			t.Errorf("NewHyperLogLog(%d) returned nil error, want error", p)
+		}
+	}
+}
+
+func TestHyperLogLog_Sparse(t *testing.T) {
+	sparse, _ := NewHyperLogLog(14)
+	dense, _ := NewHyperLogLog(14)
+	dense.densify()
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		sparse.Add(key)
+		dense.Add(key)
+		if i == 100 && !sparse.isSparse() {
+			t.Error("sketch of 100 keys isn't sparse")
+		}
+		if i%100 == 0 && sparse.Count() != dense.Count() {
+			t.Fatalf("sparse Count() %d, dense Count() %d after %d keys", sparse.Count(), dense.Count(), i+1)
+		}
+	}
+	if sparse.isSparse() {
+		t.Error("sketch of 5000 keys over 16384 registers is still sparse")
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	for _, sizes := range [][2]int{{50, 80}, {50, 20000}, {20000, 50}, {20000, 30000}} {
+		a, _ := NewHyperLogLog(12)
+		b, _ := NewHyperLogLog(12)
+		union, _ := NewHyperLogLog(12)
+		// Both streams overlap in half of the smallest one.
+		for i := 0; i < sizes[0]; i++ {
+			a.Add(fmt.Sprintf("key-%d", i))
+			union.Add(fmt.Sprintf("key-%d", i))
+		}
+		offset := sizes[0] - minInts(sizes[0], sizes[1])/2
+		for i := offset; i < offset+sizes[1]; i++ {
+			b.Add(fmt.Sprintf("key-%d", i))
+			union.Add(fmt.Sprintf("key-%d", i))
+		}
+		if err := a.Merge(b); err != nil {
+			t.Fatalf("Merge() returned unexpected error; %v", err)
+		}
+		// Registers keep maximums, so the merge is exactly the sketch of the union.
+		if a.Count() != union.Count() {
+			t.Errorf("sizes %v: merged Count() %d, union Count() %d", sizes, a.Count(), union.Count())
+		}
+	}
+	a, _ := NewHyperLogLog(12)
+	b, _ := NewHyperLogLog(10)
+	if err := a.Merge(b); err == nil {
+		t.Error("Merge() returned nil error for sketches of different precision, want error")
+	}
+}
@@ -0,0 +1,425 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FlowEdge is an edge of a flow network along with the flow it carries. The capacity of the edge
+// is its weight.
+type FlowEdge struct {
+	Edge
+	Flow int
+}
+
+// MaxFlow is the result of a maximum flow computation: the flow through every edge and a minimum
+// cut separating the source from the sink.
+type MaxFlow struct {
+	value int
+	edges []FlowEdge
+	// sourceSide tells whether each vertex is reachable from the source in the residual network.
+	sourceSide []bool
+}
+
+// Value returns the amount of flow sent from the source to the sink.
+func (f *MaxFlow) Value() int {
+	return f.value
+}
+
+// Edges returns every edge of the network with its flow, in the order reported by Graph.Edges.
+func (f *MaxFlow) Edges() []FlowEdge {
+	return append([]FlowEdge(nil), f.edges...)
+}
+
+// MinCut returns, in increasing order, the vertices on the source side of a minimum cut, and the
+// edges crossing it. The capacities of the cut edges add up to the value of the flow.
+func (f *MaxFlow) MinCut() ([]int, []Edge) {
+	var side []int
+	for v, ok := range f.sourceSide {
+		if ok {
+			side = append(side, v)
+		}
+	}
+	var cut []Edge
+	for _, e := range f.edges {
+		if f.sourceSide[e.From] && !f.sourceSide[e.To] {
+			cut = append(cut, e.Edge)
+		}
+	}
+	return side, cut
+}
+
+// flowNetwork is the residual network of a graph. Edge i of the graph is arc 2i, and its reverse
+// arc, holding the flow that can be pushed back, is 2i+1, so the reverse of arc a is a^1.
+type flowNetwork struct {
+	edges []Edge
+	to    []int
+	// residual is the capacity left on each arc.
+	residual []int
+	// arcs holds the indices of the arcs leaving each vertex.
+	arcs [][]int
+}
+
+// flowNetwork validates the source and sink of a flow in g and returns its residual network.
+func (g *Graph) flowNetwork(s, t int) (*flowNetwork, error) {
+	if err := g.validateDirected(); err != nil {
+		return nil, err
+	}
+	if err := g.validate(s, t); err != nil {
+		return nil, err
+	}
+	if s == t {
+		return nil, fmt.Errorf("source and sink must be different vertices, got %d", s)
+	}
+	if err := g.validateNonNegative(); err != nil {
+		return nil, err
+	}
+	edges := g.graphEdges()
+	nw := &flowNetwork{
+		edges:    edges,
+		to:       make([]int, 2*len(edges)),
+		residual: make([]int, 2*len(edges)),
+		arcs:     make([][]int, len(g.adj)),
+	}
+	for i, e := range edges {
+		nw.to[2*i], nw.residual[2*i] = e.To, e.Weight
+		nw.to[2*i+1] = e.From
+		nw.arcs[e.From] = append(nw.arcs[e.From], 2*i)
+		nw.arcs[e.To] = append(nw.arcs[e.To], 2*i+1)
+	}
+	return nw, nil
+}
+
+// push sends d units of flow through arc a.
+func (nw *flowNetwork) push(a, d int) {
+	nw.residual[a] -= d
+	nw.residual[a^1] += d
+}
+
+// levels returns the number of arcs on the shortest residual path from s to each vertex, -1 for
+// the vertices unreachable from s.
+func (nw *flowNetwork) levels(s int) []int {
+	level := make([]int, len(nw.arcs))
+	for v := range level {
+		level[v] = -1
+	}
+	// Every vertex is enqueued at most once.
+	q := NewArrayBasedQueue(uint(len(nw.arcs)))
+	level[s] = 0
+	q.Push(s)
+	for q.Size() > 0 {
+		u, _ := q.Pop()
+		for _, a := range nw.arcs[u.(int)] {
+			if v := nw.to[a]; nw.residual[a] > 0 && level[v] == -1 {
+				level[v] = level[u.(int)] + 1
+				q.Push(v)
+			}
+		}
+	}
+	return level
+}
+
+// result reads the flow of every edge off the residual network. The source side of the minimum
+// cut is made of the vertices still reachable from s.
+func (nw *flowNetwork) result(s, value int) *MaxFlow {
+	f := &MaxFlow{value: value, edges: make([]FlowEdge, len(nw.edges))}
+	for i, e := range nw.edges {
+		f.edges[i] = FlowEdge{Edge: e, Flow: e.Weight - nw.residual[2*i]}
+	}
+	level := nw.levels(s)
+	f.sourceSide = make([]bool, len(level))
+	for v, l := range level {
+		f.sourceSide[v] = l != -1
+	}
+	return f
+}
+
+// EdmondsKarp computes a maximum flow from s to t in a directed graph whose edge weights are the
+// non-negative capacities. It augments the flow along shortest residual paths found with a BFS,
+// so it takes O(nm²) time.
+func (g *Graph) EdmondsKarp(s, t int) (*MaxFlow, error) {
+	nw, err := g.flowNetwork(s, t)
+	if err != nil {
+		return nil, err
+	}
+	n := len(g.adj)
+	// via is the arc used to reach each vertex in the current BFS, -1 if it wasn't reached.
+	via := make([]int, n)
+	value := 0
+	for {
+		for v := range via {
+			via[v] = -1
+		}
+		// Every vertex is enqueued at most once.
+		q := NewArrayBasedQueue(uint(n))
+		q.Push(s)
+		for q.Size() > 0 && via[t] == -1 {
+			u, _ := q.Pop()
+			for _, a := range nw.arcs[u.(int)] {
+				if v := nw.to[a]; nw.residual[a] > 0 && v != s && via[v] == -1 {
+					via[v] = a
+					q.Push(v)
+				}
+			}
+		}
+		if via[t] == -1 {
+			return nw.result(s, value), nil
+		}
+		d := nw.residual[via[t]]
+		for v := t; v != s; v = nw.to[via[v]^1] {
+			d = minInts(d, nw.residual[via[v]])
+		}
+		for v := t; v != s; v = nw.to[via[v]^1] {
+			nw.push(via[v], d)
+		}
+		value += d
+	}
+}
+
+// Dinic computes a maximum flow from s to t in a directed graph whose edge weights are the
+// non-negative capacities. Each phase builds the level graph of shortest residual paths and
+// saturates it with a blocking flow found by depth-first search, so it takes O(n²m) time.
+func (g *Graph) Dinic(s, t int) (*MaxFlow, error) {
+	nw, err := g.flowNetwork(s, t)
+	if err != nil {
+		return nil, err
+	}
+	n := len(g.adj)
+	// next is the position of the first arc of each vertex that may still reach t.
+	next := make([]int, n)
+	value := 0
+	for {
+		level := nw.levels(s)
+		if level[t] == -1 {
+			return nw.result(s, value), nil
+		}
+		for v := range next {
+			next[v] = 0
+		}
+		// path holds the arcs from s to the current vertex.
+		var path []int
+		u := s
+		for {
+			if u == t {
+				d := nw.residual[path[0]]
+				for _, a := range path {
+					d = minInts(d, nw.residual[a])
+				}
+				// Retreat to the tail of the first saturated arc.
+				cut := -1
+				for i, a := range path {
+					if nw.push(a, d); cut == -1 && nw.residual[a] == 0 {
+						cut = i
+					}
+				}
+				value += d
+				path, u = path[:cut], nw.to[path[cut]^1]
+				continue
+			}
+			for ; next[u] < len(nw.arcs[u]); next[u]++ {
+				if a := nw.arcs[u][next[u]]; nw.residual[a] > 0 && level[nw.to[a]] == level[u]+1 {
+					break
+				}
+			}
+			if next[u] < len(nw.arcs[u]) {
+				a := nw.arcs[u][next[u]]
+				path, u = append(path, a), nw.to[a]
+				continue
+			}
+			// u can't reach t anymore, so the arc leading to it is useless for this phase.
+			if u == s {
+				break
+			}
+			level[u] = -1
+			u = nw.to[path[len(path)-1]^1]
+			path = path[:len(path)-1]
+			next[u]++
+		}
+	}
+}
+
+// PushRelabel computes a maximum flow from s to t in a directed graph whose edge weights are the
+// non-negative capacities. It saturates the edges leaving s and then pushes the excess of active
+// vertices, taken from a FIFO queue, downhill towards t, raising vertices that are stuck. Excess
+// that can't reach t flows back to s. It takes O(n³) time.
+func (g *Graph) PushRelabel(s, t int) (*MaxFlow, error) {
+	nw, err := g.flowNetwork(s, t)
+	if err != nil {
+		return nil, err
+	}
+	n := len(g.adj)
+	height, excess, next := make([]int, n), make([]int, n), make([]int, n)
+	active := make([]bool, n)
+	// Every vertex is in the queue at most once.
+	q := NewArrayBasedQueue(uint(n))
+	activate := func(v int) {
+		if v != s && v != t && !active[v] {
+			active[v] = true
+			q.Push(v)
+		}
+	}
+	height[s] = n
+	for _, a := range nw.arcs[s] {
+		if d := nw.residual[a]; d > 0 {
+			nw.push(a, d)
+			excess[nw.to[a]] += d
+			activate(nw.to[a])
+		}
+	}
+	for q.Size() > 0 {
+		x, _ := q.Pop()
+		u := x.(int)
+		active[u] = false
+		for excess[u] > 0 {
+			if next[u] == len(nw.arcs[u]) {
+				// Relabel: rise just above the lowest neighbor with residual capacity, which
+				// always exists since the excess came through some arc.
+				height[u] = 2 * n
+				for _, a := range nw.arcs[u] {
+					if nw.residual[a] > 0 {
+						height[u] = minInts(height[u], height[nw.to[a]]+1)
+					}
+				}
+				next[u] = 0
+				continue
+			}
+			a := nw.arcs[u][next[u]]
+			if v := nw.to[a]; nw.residual[a] > 0 && height[u] == height[v]+1 {
+				d := minInts(excess[u], nw.residual[a])
+				nw.push(a, d)
+				excess[u] -= d
+				excess[v] += d
+				activate(v)
+				continue
+			}
+			next[u]++
+		}
+	}
+	return nw.result(s, excess[t]), nil
+}
+
+// bipartition colors the vertices of an undirected graph with two colors so every edge joins
+// vertices of different colors. Returns an error if the graph isn't bipartite.
+func (g *Graph) bipartition() ([]bool, error) {
+	color := make([]bool, len(g.adj))
+	visited := make([]bool, len(g.adj))
+	for src := range g.adj {
+		if visited[src] {
+			continue
+		}
+		t, _ := g.BFS(src)
+		for it := t.Order(); it.Scan(); {
+			x, _ := it.Next()
+			v := x.(int)
+			visited[v] = true
+			if p, ok := t.Parent(v); ok {
+				color[v] = !color[p]
+			}
+		}
+	}
+	for it := g.Edges(); it.Scan(); {
+		if e := graphNextEdge(it); color[e.From] == color[e.To] {
+			return nil, fmt.Errorf("graph isn't bipartite: edge %d - %d joins two vertices of the same side",
+				e.From, e.To)
+		}
+	}
+	return color, nil
+}
+
+// HopcroftKarp returns the edges of a maximum matching of an undirected bipartite graph, sorted by
+// their endpoints and reported from their smallest endpoint. The sides are found by 2-coloring the
+// graph. Like Dinic on the unit capacity network, each phase finds a maximal set of
+// vertex-disjoint shortest augmenting paths, so it takes O(m√n) time. Returns an error if the
+// graph isn't bipartite.
+func (g *Graph) HopcroftKarp() ([]Edge, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, err
+	}
+	right, err := g.bipartition()
+	if err != nil {
+		return nil, err
+	}
+	n := len(g.adj)
+	mate, dist := make([]int, n), make([]int, n)
+	// via is the right vertex used to leave each left vertex on the current augmenting path.
+	via := make([]int, n)
+	for v := range mate {
+		mate[v] = -1
+	}
+	s := NewArrayBasedStack(uint(n))
+	for {
+		// BFS from the free left vertices over alternating paths. limit is the distance of the
+		// left vertices next to a free right vertex, so augmenting paths are the shortest.
+		q := NewArrayBasedQueue(uint(n))
+		for u := range dist {
+			if dist[u] = -1; !right[u] && mate[u] == -1 {
+				dist[u] = 0
+				q.Push(u)
+			}
+		}
+		limit := -1
+		for q.Size() > 0 {
+			x, _ := q.Pop()
+			u := x.(int)
+			if limit != -1 && dist[u] >= limit {
+				continue
+			}
+			for it := g.adj[u].Iterator(); it.Scan(); {
+				switch w := mate[graphNextEdge(it).To]; {
+				case w == -1:
+					limit = dist[u]
+				case dist[w] == -1:
+					dist[w] = dist[u] + 1
+					q.Push(w)
+				}
+			}
+		}
+		if limit == -1 {
+			break
+		}
+		for root := range g.adj {
+			if right[root] || mate[root] != -1 {
+				continue
+			}
+			s.Push(&dfsFrame{v: root, edges: g.adj[root].Iterator()})
+			for s.Size() > 0 {
+				top, _ := s.Top()
+				f := top.(*dfsFrame)
+				if !f.edges.Scan() {
+					// No augmenting path goes through f.v in this phase.
+					dist[f.v] = -1
+					s.Pop()
+					continue
+				}
+				v := graphNextEdge(f.edges).To
+				switch w := mate[v]; {
+				case w == -1 && dist[f.v] == limit:
+					// Flip the path: every left vertex on the stack takes the right vertex it left by.
+					via[f.v] = v
+					for s.Size() > 0 {
+						x, _ := s.Pop()
+						u := x.(*dfsFrame).v
+						mate[u], mate[via[u]] = via[u], u
+					}
+				case w != -1 && dist[w] == dist[f.v]+1:
+					via[f.v] = v
+					s.Push(&dfsFrame{v: w, edges: g.adj[w].Iterator()})
+				}
+			}
+		}
+	}
+	var matching []Edge
+	for it := g.Edges(); it.Scan(); {
+		e := graphNextEdge(it)
+		if mate[e.From] == e.To && mate[e.To] == e.From {
+			matching = append(matching, e)
+			// Parallel edges must not be reported twice.
+			mate[e.From], mate[e.To] = -1, -1
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		a, b := matching[i], matching[j]
+		return a.From < b.From || (a.From == b.From && a.To < b.To)
+	})
+	return matching, nil
+}
@@ -0,0 +1,226 @@
+package ads
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// maxFlowAlgorithms lists every maximum flow implementation.
+var maxFlowAlgorithms = []struct {
+	name string
+	run  func(g *Graph, s, t int) (*MaxFlow, error)
+}{
+	{name: "edmonds-karp", run: (*Graph).EdmondsKarp},
+	{name: "dinic", run: (*Graph).Dinic},
+	{name: "push-relabel", run: (*Graph).PushRelabel},
+}
+
+// checkMaxFlow verifies that f is a feasible flow from src to sink in g whose value matches the
+// capacity of its minimum cut, which proves it's maximum.
+func checkMaxFlow(t *testing.T, g *Graph, f *MaxFlow, src, sink int) {
+	t.Helper()
+	edges := f.Edges()
+	if diff := cmp.Diff(g.graphEdges(), flowEdgesToEdges(edges)); diff != "" {
+		t.Fatalf("flow edges mismatch (-want +got):\n%s", diff)
+	}
+	net := make([]int, g.Order())
+	for _, e := range edges {
+		if e.Flow < 0 || e.Flow > e.Weight {
+			t.Fatalf("edge %v carries flow %d outside [0, %d]", e.Edge, e.Flow, e.Weight)
+		}
+		net[e.From] -= e.Flow
+		net[e.To] += e.Flow
+	}
+	for v, d := range net {
+		switch {
+		case v == src && d != -f.Value(), v == sink && d != f.Value():
+			t.Fatalf("vertex %d has net inflow %d, flow value is %d", v, d, f.Value())
+		case v != src && v != sink && d != 0:
+			t.Fatalf("flow isn't conserved at vertex %d, net inflow %d", v, d)
+		}
+	}
+	side, cut := f.MinCut()
+	inSide := make([]bool, g.Order())
+	for _, v := range side {
+		inSide[v] = true
+	}
+	if !inSide[src] || inSide[sink] {
+		t.Fatalf("cut %v doesn't separate %d from %d", side, src, sink)
+	}
+	capacity := 0
+	for _, e := range cut {
+		if !inSide[e.From] || inSide[e.To] {
+			t.Fatalf("edge %v doesn't cross the cut %v", e, side)
+		}
+		capacity += e.Weight
+	}
+	if capacity != f.Value() {
+		t.Fatalf("cut capacity %d, flow value %d", capacity, f.Value())
+	}
+}
+
+func flowEdgesToEdges(edges []FlowEdge) []Edge {
+	r := make([]Edge, len(edges))
+	for i, e := range edges {
+		r[i] = e.Edge
+	}
+	return r
+}
+
+func TestGraph_MaxFlow(t *testing.T) {
+	// CLRS, figure 26.1: s=0, v1=1, ..., v4=4, t=5.
+	g := newWeightedTestGraph(t, 6, Directed, [][3]int{
+		{0, 1, 16}, {0, 2, 13}, {1, 3, 12}, {2, 1, 4}, {2, 4, 14},
+		{3, 2, 9}, {3, 5, 20}, {4, 3, 7}, {4, 5, 4},
+	})
+	for _, a := range maxFlowAlgorithms {
+		t.Run(a.name, func(t *testing.T) {
+			f, err := a.run(g, 0, 5)
+			if err != nil {
+				t.Fatalf("returned unexpected error; %v", err)
+			}
+			if f.Value() != 23 {
+				t.Errorf("Value(): %d, want 23", f.Value())
+			}
+			checkMaxFlow(t, g, f, 0, 5)
+			side, cut := f.MinCut()
+			if diff := cmp.Diff([]int{0, 1, 2, 4}, side); diff != "" {
+				t.Errorf("MinCut() side mismatch (-want +got):\n%s", diff)
+			}
+			want := []Edge{
+				{From: 1, To: 3, Weight: 12}, {From: 4, To: 3, Weight: 7}, {From: 4, To: 5, Weight: 4},
+			}
+			if diff := cmp.Diff(want, cut); diff != "" {
+				t.Errorf("MinCut() edges mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGraph_MaxFlowErrors(t *testing.T) {
+	negative := newWeightedTestGraph(t, 2, Directed, [][3]int{{0, 1, -1}})
+	tests := []struct {
+		name string
+		g    *Graph
+		s, t int
+	}{
+		{name: "undirected", g: NewGraph(2, 0), s: 0, t: 1},
+		{name: "invalid vertex", g: NewGraph(2, Directed), s: 0, t: 2},
+		{name: "source is sink", g: NewGraph(2, Directed), s: 1, t: 1},
+		{name: "negative capacity", g: negative, s: 0, t: 1},
+	}
+	for _, test := range tests {
+		for _, a := range maxFlowAlgorithms {
+			if _, err := a.run(test.g, test.s, test.t); err == nil {
+				t.Errorf("%s: %s returned nil error, want error", test.name, a.name)
+			}
+		}
+	}
+}
+
+func TestGraph_MaxFlowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		n := 2 + r.Intn(7)
+		g := randomGraph(r, n, r.Intn(4*n), 10, Directed)
+		s, sink := r.Intn(n), r.Intn(n-1)
+		if sink >= s {
+			sink++
+		}
+		// The capacity of the smallest cut, found by trying every source side.
+		want := -1
+		for mask := 0; mask < 1<<n; mask++ {
+			if mask&(1<<s) == 0 || mask&(1<<sink) != 0 {
+				continue
+			}
+			capacity := 0
+			for _, e := range g.graphEdges() {
+				if mask&(1<<e.From) != 0 && mask&(1<<e.To) == 0 {
+					capacity += e.Weight
+				}
+			}
+			if want == -1 || capacity < want {
+				want = capacity
+			}
+		}
+		for _, a := range maxFlowAlgorithms {
+			f, err := a.run(g, s, sink)
+			if err != nil {
+				t.Fatalf("%s returned unexpected error; %v", a.name, err)
+			}
+			if f.Value() != want {
+				t.Fatalf("%s: Value() %d, want %d", a.name, f.Value(), want)
+			}
+			checkMaxFlow(t, g, f, s, sink)
+		}
+	}
+}
+
+// checkMatching verifies that matching is made of edges of g and no vertex is matched twice.
+func checkMatching(t *testing.T, g *Graph, matching []Edge) {
+	t.Helper()
+	matched := make([]bool, g.Order())
+	for _, e := range matching {
+		if ok, _ := g.HasEdge(e.From, e.To); !ok || e.From > e.To {
+			t.Fatalf("matching uses a missing or unordered edge %v", e)
+		}
+		if matched[e.From] || matched[e.To] {
+			t.Fatalf("edge %v shares a vertex with another matched edge", e)
+		}
+		matched[e.From], matched[e.To] = true, true
+	}
+}
+
+func TestGraph_HopcroftKarp(t *testing.T) {
+	// Left side 0-3, right side 4-7. A perfect matching needs 0 - 5 since 1 only reaches 4.
+	g := newTestGraph(t, 8, 0, [][2]int{
+		{0, 4}, {0, 5}, {1, 4}, {2, 4}, {2, 6}, {3, 6}, {3, 7}, {2, 7},
+	})
+	matching, err := g.HopcroftKarp()
+	if err != nil {
+		t.Fatalf("HopcroftKarp() returned unexpected error; %v", err)
+	}
+	if len(matching) != 4 || matching[0] != (Edge{From: 0, To: 5, Weight: 1}) {
+		t.Errorf("HopcroftKarp() returned %v, want a perfect matching using 0 - 5", matching)
+	}
+	checkMatching(t, g, matching)
+	if _, err := NewGraph(2, Directed).HopcroftKarp(); err == nil {
+		t.Error("HopcroftKarp() returned nil error for a directed graph, want error")
+	}
+	triangle := newTestGraph(t, 3, 0, [][2]int{{0, 1}, {1, 2}, {2, 0}})
+	if _, err := triangle.HopcroftKarp(); err == nil {
+		t.Error("HopcroftKarp() returned nil error for an odd cycle, want error")
+	}
+}
+
+func TestGraph_HopcroftKarpRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		left, right := 1+r.Intn(10), 1+r.Intn(10)
+		n := left + right
+		g := NewGraph(n, 0)
+		// The same matching problem as a unit capacity network from n to n+1.
+		network := NewGraph(n+2, Directed|Weighted)
+		for u := 0; u < left; u++ {
+			network.AddWeightedEdge(n, u, 1)
+		}
+		for v := left; v < n; v++ {
+			network.AddWeightedEdge(v, n+1, 1)
+		}
+		for j := r.Intn(3 * n); j > 0; j-- {
+			u, v := r.Intn(left), left+r.Intn(right)
+			g.AddEdge(u, v)
+			network.AddWeightedEdge(u, v, 1)
+		}
+		matching, err := g.HopcroftKarp()
+		if err != nil {
+			t.Fatalf("HopcroftKarp() returned unexpected error; %v", err)
+		}
+		checkMatching(t, g, matching)
+		if f, _ := network.Dinic(n, n+1); len(matching) != f.Value() {
+			t.Fatalf("HopcroftKarp() found %d edges, maximum matching has %d", len(matching), f.Value())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ads
+
+import "fmt"
+
+// OrderStatisticTree is an AVLTree whose nodes are augmented with subtree sizes, so the k-th
+// smallest element and the rank of any element are found in O(log n).
+type OrderStatisticTree struct {
+	AVLTree
+}
+
+// NewOrderStatisticTree returns an empty order statistic tree ordered by the given comparator.
+func NewOrderStatisticTree(cmp Comparator) *OrderStatisticTree {
+	return &OrderStatisticTree{AVLTree: AVLTree{cmp: cmp}}
+}
+
+// Select returns the k-th smallest element of the tree, k being zero-based: Select(0) is the
+// minimum and Select(Rank(v)) is v for any stored v.
+func (t *OrderStatisticTree) Select(k int) (interface{}, error) {
+	if k < 0 || k >= t.Size() {
+		return nil, fmt.Errorf("tree size is %d, got invalid rank %d", t.Size(), k)
+	}
+	n := t.root
+	for {
+		l := bstSize(n.left)
+		switch {
+		case k < l:
+			n = n.left
+		case k > l:
+			k -= l + 1
+			n = n.right
+		default:
+			return n.value, nil
+		}
+	}
+}
+
+// rank returns the number of elements smaller than (or equal to, if inclusive) v.
+func (t *OrderStatisticTree) rank(v interface{}, inclusive bool) int {
+	r := 0
+	for n := t.root; n != nil; {
+		if c := t.cmp(n.value, v); c < 0 || (inclusive && c == 0) {
+			r += bstSize(n.left) + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return r
+}
+
+// Rank returns the number of elements strictly smaller than v. v doesn't need to be stored.
+func (t *OrderStatisticTree) Rank(v interface{}) int {
+	return t.rank(v, false)
+}
+
+// CountRange returns the number of elements in the closed range [lo, hi]. A nil bound means the
+// range is unbounded on that side, as in Range.
+func (t *OrderStatisticTree) CountRange(lo, hi interface{}) int {
+	if lo != nil && hi != nil && t.cmp(lo, hi) > 0 {
+		return 0
+	}
+	count := t.Size()
+	if hi != nil {
+		count = t.rank(hi, true)
+	}
+	if lo != nil {
+		count -= t.rank(lo, false)
+	}
+	return count
+}
@@ -0,0 +1,86 @@
+package ads
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestOrderStatisticTree(t *testing.T) {
+	const n = 500
+	r := rand.New(rand.NewSource(1))
+	tree := NewOrderStatisticTree(IntComparator)
+	control := map[int]bool{}
+	for i := 0; i < 3*n; i++ {
+		v := r.Intn(n)
+		if r.Intn(3) == 0 {
+			tree.Remove(v)
+			delete(control, v)
+		} else {
+			tree.Add(v)
+			control[v] = true
+		}
+		checkAVLInvariants(t, &tree.AVLTree)
+		if i%50 != 0 {
+			continue
+		}
+		sorted := make([]int, 0, len(control))
+		for k := range control {
+			sorted = append(sorted, k)
+		}
+		sort.Ints(sorted)
+		for k, want := range sorted {
+			if got, err := tree.Select(k); err != nil || got != want {
+				t.Fatalf("Select(%d): %v, %v, want %d, nil", k, got, err, want)
+			}
+		}
+		for v := -1; v <= n; v++ {
+			want := sort.SearchInts(sorted, v)
+			if got := tree.Rank(v); got != want {
+				t.Fatalf("Rank(%d): %d, want %d", v, got, want)
+			}
+		}
+		for j := 0; j < 20; j++ {
+			lo, hi := r.Intn(n+2)-1, r.Intn(n+2)-1
+			want := 0
+			for _, x := range sorted {
+				if x >= lo && x <= hi {
+					want++
+				}
+			}
+			if got := tree.CountRange(lo, hi); got != want {
+				t.Fatalf("CountRange(%d, %d): %d, want %d", lo, hi, got, want)
+			}
+		}
+		// Nil bounds leave the range open on that side.
+		mid := n / 2
+		unbounded := []struct {
+			lo, hi interface{}
+			want   int
+		}{
+			{lo: nil, hi: nil, want: len(sorted)},
+			{lo: nil, hi: mid, want: sort.SearchInts(sorted, mid+1)},
+			{lo: mid, hi: nil, want: len(sorted) - sort.SearchInts(sorted, mid)},
+		}
+		for _, test := range unbounded {
+			if got := tree.CountRange(test.lo, test.hi); got != test.want {
+				t.Fatalf("CountRange(%v, %v): %d, want %d", test.lo, test.hi, got, test.want)
+			}
+		}
+	}
+}
+
+func TestOrderStatisticTree_Errors(t *testing.T) {
+	tree := NewOrderStatisticTree(IntComparator)
+	if _, err := tree.Select(0); err == nil {
+		t.Error("Select(0) on empty tree returned nil error, want error")
+	}
+	for _, v := range []int{3, 1, 2} {
+		tree.Add(v)
+	}
+	for _, k := range []int{-1, 3} {
+		if _, err := tree.Select(k); err == nil {
+			t.Errorf("Select(%d) returned nil error, want error", k)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package ads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderedSet is a Container that keeps its unique elements sorted by a Comparator.
+type OrderedSet interface {
+	Container
+	// Min returns the smallest element of the set.
+	Min() (interface{}, error)
+	// Max returns the largest element of the set.
+	Max() (interface{}, error)
+	// Floor returns the largest element smaller than or equal to v.
+	Floor(v interface{}) (interface{}, error)
+	// Ceiling returns the smallest element greater than or equal to v.
+	Ceiling(v interface{}) (interface{}, error)
+	// Predecessor returns the largest element strictly smaller than v.
+	Predecessor(v interface{}) (interface{}, error)
+	// Successor returns the smallest element strictly greater than v.
+	Successor(v interface{}) (interface{}, error)
+	// Range returns an iterable over the elements in the closed range [lo, hi] in sorted order. A
+	// nil bound means the range is unbounded on that side.
+	Range(lo, hi interface{}) Iterable
+}
+
+// bstNode is a node of the binary search trees without parent links (AVLTree and Treap).
+type bstNode struct {
+	value       interface{}
+	left, right *bstNode
+	// rank holds the balancing information: the height in AVL trees, the heap priority in treaps.
+	rank int
+	// size is the number of nodes in the subtree rooted at this node.
+	size int
+}
+
+// bstSize returns the size of the subtree rooted at n.
+func bstSize(n *bstNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// bstFind returns the node holding v or nil.
+func bstFind(n *bstNode, cmp Comparator, v interface{}) *bstNode {
+	for n != nil {
+		c := cmp(v, n.value)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// bstLowerBound returns the node holding the smallest element greater than (or equal to, if
+// inclusive) v, or nil.
+func bstLowerBound(n *bstNode, cmp Comparator, v interface{}, inclusive bool) *bstNode {
+	var best *bstNode
+	for n != nil {
+		if c := cmp(n.value, v); c > 0 || (inclusive && c == 0) {
+			best, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return best
+}
+
+// bstUpperBound returns the node holding the largest element smaller than (or equal to, if
+// inclusive) v, or nil.
+func bstUpperBound(n *bstNode, cmp Comparator, v interface{}, inclusive bool) *bstNode {
+	var best *bstNode
+	for n != nil {
+		if c := cmp(n.value, v); c < 0 || (inclusive && c == 0) {
+			best, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return best
+}
+
+// bstValue returns the value of n or an error if n is nil.
+func bstValue(n *bstNode, what string, v interface{}) (interface{}, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%v has no %s in the tree", v, what)
+	}
+	return n.value, nil
+}
+
+// bstMin returns the smallest element of the subtree rooted at n.
+func bstMin(n *bstNode) (interface{}, error) {
+	if n == nil {
+		return nil, fmt.Errorf("empty tree")
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value, nil
+}
+
+// bstMax returns the largest element of the subtree rooted at n.
+func bstMax(n *bstNode) (interface{}, error) {
+	if n == nil {
+		return nil, fmt.Errorf("empty tree")
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, nil
+}
+
+// bstString returns a string representation of the elements in the subtree rooted at n.
+func bstString(n *bstNode) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for it := newBSTIterable(n, nil, nil, nil); it.Scan(); {
+		v, _ := it.Next()
+		if b.Len() > 1 {
+			b.WriteString(", ")
+		}
+		b.WriteString(fmt.Sprintf("%v", v))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// BinarySearchTreeIterable implements Iterable interface for AVLTree and Treap. It walks the
+// tree in order using an explicit stack.
+type BinarySearchTreeIterable struct {
+	cmp   Comparator
+	stack []*bstNode
+	// hi is the inclusive upper bound of the iteration, nil if unbounded.
+	hi interface{}
+}
+
+// newBSTIterable returns an in-order iterable over the elements in [lo, hi] of the subtree rooted
+// at n. A nil bound means the range is unbounded on that side.
+func newBSTIterable(n *bstNode, cmp Comparator, lo, hi interface{}) *BinarySearchTreeIterable {
+	it := &BinarySearchTreeIterable{cmp: cmp, hi: hi}
+	for n != nil {
+		if lo == nil || cmp(n.value, lo) >= 0 {
+			it.stack = append(it.stack, n)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return it
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *BinarySearchTreeIterable) Scan() bool {
+	if len(i.stack) == 0 {
+		return false
+	}
+	return i.hi == nil || i.cmp(i.stack[len(i.stack)-1].value, i.hi) <= 0
+}
+
+// Next returns the next element in the iterable.
+func (i *BinarySearchTreeIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	n := i.stack[len(i.stack)-1]
+	i.stack = i.stack[:len(i.stack)-1]
+	for x := n.right; x != nil; x = x.left {
+		i.stack = append(i.stack, x)
+	}
+	return n.value, nil
+}
@@ -0,0 +1,200 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func logOrderedSetSatisfaction(t *testing.T, ds string, s OrderedSet) {
+	t.Helper()
+	t.Logf("%s satisfies OrderedSet interface: %v", ds, s)
+}
+
+// TestOrderedSetInterfaceSatisfaction verifies (during compilation) that the
+// multiple ordered set implementations satisfy the OrderedSet interface.
+func TestOrderedSetInterfaceSatisfaction(t *testing.T) {
+	var s OrderedSet
+
+	s = NewRedBlackTree(IntComparator)
+	logOrderedSetSatisfaction(t, "RedBlackTree", s)
+	s = NewAVLTree(IntComparator)
+	logOrderedSetSatisfaction(t, "AVLTree", s)
+	s = NewTreap(IntComparator, 1)
+	logOrderedSetSatisfaction(t, "Treap", s)
+	s = NewOrderStatisticTree(IntComparator)
+	logOrderedSetSatisfaction(t, "OrderStatisticTree", s)
+}
+
+// orderedSetFactories lists every OrderedSet implementation checked by the conformance suite.
+var orderedSetFactories = []struct {
+	name string
+	new  func() OrderedSet
+}{
+	{name: "red-black", new: func() OrderedSet { return NewRedBlackTree(IntComparator) }},
+	{name: "avl", new: func() OrderedSet { return NewAVLTree(IntComparator) }},
+	{name: "treap", new: func() OrderedSet { return NewTreap(IntComparator, 1) }},
+}
+
+// checkBSTInvariants verifies the search order and the subtree sizes of the tree rooted at n.
+func checkBSTInvariants(t *testing.T, n *bstNode, cmp Comparator) {
+	t.Helper()
+	var walk func(n *bstNode, lo, hi interface{}) int
+	walk = func(n *bstNode, lo, hi interface{}) int {
+		if n == nil {
+			return 0
+		}
+		if lo != nil && cmp(n.value, lo) <= 0 || hi != nil && cmp(n.value, hi) >= 0 {
+			t.Fatalf("node %v breaks the search order within (%v, %v)", n.value, lo, hi)
+		}
+		size := walk(n.left, lo, n.value) + walk(n.right, n.value, hi) + 1
+		if n.size != size {
+			t.Fatalf("node %v has size %d, subtree has %d nodes", n.value, n.size, size)
+		}
+		return size
+	}
+	walk(n, nil, nil)
+}
+
+func TestOrderedSet_Conformance(t *testing.T) {
+	for _, f := range orderedSetFactories {
+		t.Run(f.name, func(t *testing.T) {
+			const n = 300
+			r := rand.New(rand.NewSource(1))
+			s := f.new()
+			control := map[int]bool{}
+			for i := 0; i < 3*n; i++ {
+				v := r.Intn(n)
+				if r.Intn(3) == 0 {
+					s.Remove(v)
+					delete(control, v)
+				} else {
+					s.Add(v)
+					control[v] = true
+				}
+				if s.Size() != len(control) {
+					t.Fatalf("Size(): %d, want %d", s.Size(), len(control))
+				}
+			}
+			sorted := sortedKeys(control)
+			if diff := cmp.Diff(sorted, iterableToSlice(t, s.Iterator())); diff != "" {
+				t.Fatalf("Iterator() produced unwanted elements (-want +got):\n%s", diff)
+			}
+			if min, err := s.Min(); err != nil || min != sorted[0] {
+				t.Errorf("Min(): %v, %v, want %v, nil", min, err, sorted[0])
+			}
+			if max, err := s.Max(); err != nil || max != sorted[len(sorted)-1] {
+				t.Errorf("Max(): %v, %v, want %v, nil", max, err, sorted[len(sorted)-1])
+			}
+			// Reference answers are computed by scanning the sorted elements.
+			for v := -1; v <= n; v++ {
+				var floor, ceiling, pred, succ interface{}
+				for _, x := range sorted {
+					switch {
+					case x.(int) < v:
+						floor, pred = x, x
+					case x.(int) == v:
+						floor, ceiling = x, x
+					case x.(int) > v:
+						if ceiling == nil {
+							ceiling = x
+						}
+						if succ == nil {
+							succ = x
+						}
+					}
+				}
+				checks := []struct {
+					name string
+					f    func(interface{}) (interface{}, error)
+					want interface{}
+				}{
+					{"Floor", s.Floor, floor}, {"Ceiling", s.Ceiling, ceiling},
+					{"Predecessor", s.Predecessor, pred}, {"Successor", s.Successor, succ},
+				}
+				for _, c := range checks {
+					got, err := c.f(v)
+					if c.want == nil && err == nil {
+						t.Fatalf("%s(%d): %v, want error", c.name, v, got)
+					}
+					if c.want != nil && (err != nil || got != c.want) {
+						t.Fatalf("%s(%d): %v, %v, want %v, nil", c.name, v, got, err, c.want)
+					}
+				}
+			}
+			for i := 0; i < 50; i++ {
+				lo, hi := r.Intn(n+2)-1, r.Intn(n+2)-1
+				want := []interface{}{}
+				for _, x := range sorted {
+					if x.(int) >= lo && x.(int) <= hi {
+						want = append(want, x)
+					}
+				}
+				if diff := cmp.Diff(want, iterableToSlice(t, s.Range(lo, hi))); diff != "" {
+					t.Fatalf("Range(%d, %d) produced unwanted elements (-want +got):\n%s", lo, hi, diff)
+				}
+			}
+			// Nil bounds leave the range open on that side.
+			mid := sorted[len(sorted)/2]
+			unbounded := []struct {
+				lo, hi interface{}
+				want   []interface{}
+			}{
+				{lo: nil, hi: nil, want: sorted},
+				{lo: nil, hi: mid, want: sorted[:len(sorted)/2+1]},
+				{lo: mid, hi: nil, want: sorted[len(sorted)/2:]},
+			}
+			for _, test := range unbounded {
+				got := iterableToSlice(t, s.Range(test.lo, test.hi))
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Fatalf("Range(%v, %v) produced unwanted elements (-want +got):\n%s",
+						test.lo, test.hi, diff)
+				}
+			}
+			s.Empty()
+			if s.Size() != 0 || s.Contains(sorted[0]) {
+				t.Errorf("Empty() left elements in the set")
+			}
+			if _, err := s.Min(); err == nil {
+				t.Error("Min() on empty set returned nil error, want error")
+			}
+			if _, err := s.Max(); err == nil {
+				t.Error("Max() on empty set returned nil error, want error")
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet(b *testing.B) {
+	const n = 10000
+	r := rand.New(rand.NewSource(1))
+	keys := r.Perm(n)
+	for _, f := range orderedSetFactories {
+		b.Run(fmt.Sprintf("%s/insert-heavy", f.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := f.new()
+				for _, k := range keys {
+					s.Add(k)
+				}
+				for _, k := range keys[:n/10] {
+					s.Contains(k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/lookup-heavy", f.name), func(b *testing.B) {
+			s := f.new()
+			for _, k := range keys {
+				s.Add(k)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, k := range keys {
+					s.Contains(k)
+					s.Contains(k + n)
+				}
+			}
+		})
+	}
+}
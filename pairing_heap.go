@@ -0,0 +1,158 @@
+package ads
+
+import "fmt"
+
+// pairingHeapNode is a node of a pairing heap. Children are stored as a linked list of siblings,
+// prev points to the left sibling or, for the first child, to the parent.
+type pairingHeapNode struct {
+	item                 *HeapItem
+	child, sibling, prev *pairingHeapNode
+	owner                *heapOwner
+}
+
+// PairingHeap is a Heap represented as a multi-way tree with amortized O(1) insertion and merging.
+type PairingHeap struct {
+	root   *pairingHeapNode
+	length int
+	owner  *heapOwner
+}
+
+// NewPairingHeap returns an empty pairing heap.
+func NewPairingHeap() *PairingHeap {
+	return &PairingHeap{owner: &heapOwner{}}
+}
+
+// meld links two heap-ordered trees and returns the resulting root.
+func (h *PairingHeap) meld(a, b *pairingHeapNode) *pairingHeapNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.item.Key < a.item.Key {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.sibling, a.prev = nil, nil
+	return a
+}
+
+// Insert a new value with the given key, returns a handle to the stored item.
+func (h *PairingHeap) Insert(key int, v interface{}) *HeapItem {
+	item := &HeapItem{Key: key, Value: v}
+	n := &pairingHeapNode{item: item, owner: h.owner}
+	item.node = n
+	h.root = h.meld(h.root, n)
+	h.length++
+	return item
+}
+
+// FindMin returns the item with the smallest key without removing it.
+func (h *PairingHeap) FindMin() (*HeapItem, error) {
+	if h.root == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	return h.root.item, nil
+}
+
+// DeleteMin removes and returns the item with the smallest key. Children of the root are
+// combined using the standard two-pass strategy.
+func (h *PairingHeap) DeleteMin() (*HeapItem, error) {
+	if h.root == nil {
+		return nil, fmt.Errorf("empty heap")
+	}
+	root := h.root
+	var pairs []*pairingHeapNode
+	// First pass: meld children in pairs from left to right.
+	for c := root.child; c != nil; {
+		a, b := c, c.sibling
+		c = nil
+		if b != nil {
+			c = b.sibling
+			b.sibling, b.prev = nil, nil
+		}
+		a.sibling, a.prev = nil, nil
+		pairs = append(pairs, h.meld(a, b))
+	}
+	// Second pass: meld the resulting trees from right to left.
+	var r *pairingHeapNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		r = h.meld(pairs[i], r)
+	}
+	h.root = r
+	h.length--
+	root.item.node = nil
+	return root.item, nil
+}
+
+// DecreaseKey lowers the key of an item stored in the heap.
+func (h *PairingHeap) DecreaseKey(item *HeapItem, key int) error {
+	if err := validateDecreaseKey(item, key); err != nil {
+		return err
+	}
+	n, ok := item.node.(*pairingHeapNode)
+	if !ok || n.item != item || n.owner.find() != h.owner {
+		return fmt.Errorf("item %v is not stored in the heap", item)
+	}
+	item.Key = key
+	if n == h.root {
+		return nil
+	}
+	// Cut the subtree rooted at n and meld it back with the root.
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.sibling, n.prev = nil, nil
+	h.root = h.meld(h.root, n)
+	return nil
+}
+
+// Merge moves all the items of the given heap into this one, leaving the other heap empty.
+func (h *PairingHeap) Merge(other Heap) error {
+	o, ok := other.(*PairingHeap)
+	if !ok {
+		return fmt.Errorf("cannot merge %T into %T", other, h)
+	}
+	if o == h {
+		return nil
+	}
+	h.root = h.meld(h.root, o.root)
+	h.length += o.length
+	o.owner.parent = h.owner
+	o.root, o.length, o.owner = nil, 0, &heapOwner{}
+	return nil
+}
+
+// Size returns the number of elements stored in the heap.
+func (h *PairingHeap) Size() int {
+	return h.length
+}
+
+// Empty removes all elements from the heap.
+func (h *PairingHeap) Empty() {
+	// Detach every item from its node so stale handles are rejected by DecreaseKey.
+	stack := []*pairingHeapNode{}
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for c := n.child; c != nil; c = c.sibling {
+			stack = append(stack, c)
+		}
+		n.item.node = nil
+	}
+	h.root, h.length = nil, 0
+}
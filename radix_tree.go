@@ -0,0 +1,279 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// radixNode holds the label of the edge coming from its parent, the value of the key ending at it
+// and its children sorted by the first rune of their labels.
+type radixNode struct {
+	label    []rune
+	value    interface{}
+	terminal bool
+	children []*radixNode
+}
+
+// child returns the position where the child whose label starts with r is (or would be) and
+// whether it exists.
+func (n *radixNode) child(r rune) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= r })
+	return i, i < len(n.children) && n.children[i].label[0] == r
+}
+
+// removeChild deletes the i-th child of n.
+func (n *radixNode) removeChild(i int) {
+	copy(n.children[i:], n.children[i+1:])
+	// Avoid memory leaks (free references for garbage collector)
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// absorbChild merges n with its only child, keeping n in the tree.
+func (n *radixNode) absorbChild() {
+	c := n.children[0]
+	label := make([]rune, 0, len(n.label)+len(c.label))
+	n.label = append(append(label, n.label...), c.label...)
+	n.value, n.terminal, n.children = c.value, c.terminal, c.children
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// RadixTree is a compressed prefix tree: chains of nodes with a single child and no key are
+// merged into one edge labeled with several runes, so it has at most 2n nodes for n keys.
+type RadixTree struct {
+	root   radixNode
+	length int
+}
+
+// NewRadixTree returns an empty radix tree.
+func NewRadixTree() *RadixTree {
+	return &RadixTree{}
+}
+
+// find follows the runes of k and returns the node whose path spells k, or nil.
+func (t *RadixTree) find(k []rune) *radixNode {
+	n := &t.root
+	for len(k) > 0 {
+		i, ok := n.child(k[0])
+		if !ok {
+			return nil
+		}
+		c := n.children[i]
+		if commonPrefix(c.label, k) < len(c.label) {
+			return nil
+		}
+		n, k = c, k[len(c.label):]
+	}
+	return n
+}
+
+// Insert sets or updates the value stored at the given key.
+func (t *RadixTree) Insert(k string, v interface{}) {
+	n, rs := &t.root, []rune(k)
+	for len(rs) > 0 {
+		i, ok := n.child(rs[0])
+		if !ok {
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = &radixNode{label: rs}
+			n = n.children[i]
+			break
+		}
+		c := n.children[i]
+		p := commonPrefix(c.label, rs)
+		if p < len(c.label) {
+			// Split the edge where the key diverges from it.
+			mid := &radixNode{label: c.label[:p:p], children: []*radixNode{c}}
+			c.label = c.label[p:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, rs = c, rs[p:]
+	}
+	if !n.terminal {
+		n.terminal = true
+		t.length++
+	}
+	n.value = v
+}
+
+// Get the value stored in the given key. Returns nil, false if it doesn't exist.
+func (t *RadixTree) Get(k string) (interface{}, bool) {
+	if n := t.find([]rune(k)); n != nil && n.terminal {
+		return n.value, true
+	}
+	return nil, false
+}
+
+// Delete the value stored at the given key (if exists). Nodes are merged back to keep the tree
+// compressed.
+func (t *RadixTree) Delete(k string) {
+	var parent *radixNode
+	n, rs := &t.root, []rune(k)
+	for len(rs) > 0 {
+		i, ok := n.child(rs[0])
+		if !ok {
+			return
+		}
+		c := n.children[i]
+		if commonPrefix(c.label, rs) < len(c.label) {
+			return
+		}
+		parent, n, rs = n, c, rs[len(c.label):]
+	}
+	if !n.terminal {
+		return
+	}
+	n.terminal, n.value = false, nil
+	t.length--
+	if n == &t.root {
+		return
+	}
+	switch len(n.children) {
+	case 0:
+		i, _ := parent.child(n.label[0])
+		parent.removeChild(i)
+		if parent != &t.root && !parent.terminal && len(parent.children) == 1 {
+			parent.absorbChild()
+		}
+	case 1:
+		n.absorbChild()
+	}
+}
+
+// HasPrefix returns whether any stored key starts with p.
+func (t *RadixTree) HasPrefix(p string) bool {
+	n, _ := t.prefixNode([]rune(p))
+	// Every node but the root is on the path of some key.
+	return n != nil && (n.terminal || len(n.children) > 0)
+}
+
+// prefixNode returns the first node whose path starts with p and the key spelled by that path,
+// or nil if no path starts with p.
+func (t *RadixTree) prefixNode(p []rune) (*radixNode, []rune) {
+	n, key := &t.root, []rune{}
+	for len(p) > 0 {
+		i, ok := n.child(p[0])
+		if !ok {
+			return nil, nil
+		}
+		c := n.children[i]
+		m := commonPrefix(c.label, p)
+		if m < len(c.label) && m < len(p) {
+			return nil, nil
+		}
+		key = append(key, c.label...)
+		n, p = c, p[m:]
+	}
+	return n, key
+}
+
+// KeysWithPrefix returns an iterable over the stored keys starting with p in lexicographic order.
+func (t *RadixTree) KeysWithPrefix(p string) Iterable {
+	it := &RadixTreeIterable{}
+	if n, key := t.prefixNode([]rune(p)); n != nil {
+		it.stack = []radixFrame{{n: n, key: key}}
+		it.advance()
+	}
+	return it
+}
+
+// LongestPrefixOf returns the longest stored key that is a prefix of s and its value. Returns
+// "", nil, false if there's none.
+func (t *RadixTree) LongestPrefixOf(s string) (string, interface{}, bool) {
+	rs := []rune(s)
+	n, depth := &t.root, 0
+	end, value, found := 0, t.root.value, t.root.terminal
+	for depth < len(rs) {
+		i, ok := n.child(rs[depth])
+		if !ok {
+			break
+		}
+		c := n.children[i]
+		if commonPrefix(c.label, rs[depth:]) < len(c.label) {
+			break
+		}
+		n, depth = c, depth+len(c.label)
+		if n.terminal {
+			end, value, found = depth, n.value, true
+		}
+	}
+	if !found {
+		return "", nil, false
+	}
+	// Convert the prefix length from runes to bytes.
+	for j := range s {
+		if end == 0 {
+			return s[:j], value, true
+		}
+		end--
+	}
+	return s, value, true
+}
+
+// Size returns the number of keys stored in the radix tree.
+func (t *RadixTree) Size() int {
+	return t.length
+}
+
+// Empty removes all keys from the radix tree.
+func (t *RadixTree) Empty() {
+	t.root = radixNode{}
+	t.length = 0
+}
+
+// radixFrame is a node pending to be visited and the key spelled by the path to it.
+type radixFrame struct {
+	n   *radixNode
+	key []rune
+}
+
+// RadixTreeIterable implements Iterable interface for RadixTree, returning string keys. It walks
+// the tree in preorder using an explicit stack.
+type RadixTreeIterable struct {
+	stack []radixFrame
+	// next is the key returned by the next call to Next, valid if hasNext.
+	next    string
+	hasNext bool
+}
+
+// advance walks the tree until the next key is found.
+func (i *RadixTreeIterable) advance() {
+	i.hasNext = false
+	for len(i.stack) > 0 && !i.hasNext {
+		f := i.stack[len(i.stack)-1]
+		i.stack = i.stack[:len(i.stack)-1]
+		for j := len(f.n.children) - 1; j >= 0; j-- {
+			c := f.n.children[j]
+			key := make([]rune, 0, len(f.key)+len(c.label))
+			key = append(append(key, f.key...), c.label...)
+			i.stack = append(i.stack, radixFrame{n: c, key: key})
+		}
+		if f.n.terminal {
+			i.next, i.hasNext = string(f.key), true
+		}
+	}
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *RadixTreeIterable) Scan() bool {
+	return i.hasNext
+}
+
+// Next returns the next element in the iterable.
+func (i *RadixTreeIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	k := i.next
+	i.advance()
+	return k, nil
+}
@@ -0,0 +1,68 @@
+package ads
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkRadixTreeInvariants verifies that children are sorted by their distinct first runes and
+// that every node but the root has a non-empty label and either a key or several children.
+func checkRadixTreeInvariants(t *testing.T, tree *RadixTree) {
+	t.Helper()
+	keys := 0
+	var check func(n *radixNode, root bool)
+	check = func(n *radixNode, root bool) {
+		if !root && len(n.label) == 0 {
+			t.Fatal("found a node with an empty label")
+		}
+		if !root && !n.terminal && len(n.children) < 2 {
+			t.Fatalf("node %q has no key and %d children", string(n.label), len(n.children))
+		}
+		if n.terminal {
+			keys++
+		}
+		for i, c := range n.children {
+			if i > 0 && n.children[i-1].label[0] >= c.label[0] {
+				t.Fatalf("children %q and %q are not sorted", string(n.children[i-1].label), string(c.label))
+			}
+			check(c, false)
+		}
+	}
+	check(&tree.root, true)
+	if keys != tree.Size() {
+		t.Fatalf("Size(): %d, tree has %d keys", tree.Size(), keys)
+	}
+}
+
+func TestRadixTree_Compression(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewRadixTree()
+	for i := 0; i < 3000; i++ {
+		k := randomRuneString(r, 6)
+		if r.Intn(2) == 0 {
+			tree.Delete(k)
+		} else {
+			tree.Insert(k, i)
+		}
+		checkRadixTreeInvariants(t, tree)
+	}
+}
+
+func TestRadixTree_SplitAndMerge(t *testing.T) {
+	tree := NewRadixTree()
+	tree.Insert("romane", 1)
+	tree.Insert("romanus", 2)
+	tree.Insert("romulus", 3)
+	if got := string(tree.root.children[0].label); got != "rom" {
+		t.Errorf("edge label: %q, want %q", got, "rom")
+	}
+	tree.Delete("romulus")
+	if got := string(tree.root.children[0].label); got != "roman" {
+		t.Errorf("edge label after Delete(): %q, want %q", got, "roman")
+	}
+	tree.Delete("romanus")
+	if got := string(tree.root.children[0].label); got != "romane" {
+		t.Errorf("edge label after Delete(): %q, want %q", got, "romane")
+	}
+	checkRadixTreeInvariants(t, tree)
+}
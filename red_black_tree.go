@@ -0,0 +1,422 @@
+package ads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rbNode is a node of a red-black tree.
+type rbNode struct {
+	value               interface{}
+	left, right, parent *rbNode
+	red                 bool
+}
+
+// RedBlackTree is a self-balancing binary search tree that stores unique elements sorted by a
+// Comparator. See Introduction to Algorithms (CLRS), chapter 13 for reference.
+type RedBlackTree struct {
+	root *rbNode
+	// sentinel is a black node used in place of nil children and as the parent of the root.
+	sentinel *rbNode
+	cmp      Comparator
+	length   int
+}
+
+// NewRedBlackTree returns an empty red-black tree ordered by the given comparator.
+func NewRedBlackTree(cmp Comparator) *RedBlackTree {
+	s := &rbNode{}
+	s.left, s.right, s.parent = s, s, s
+	return &RedBlackTree{root: s, sentinel: s, cmp: cmp}
+}
+
+// find returns the node holding v, or the sentinel if v isn't stored.
+func (t *RedBlackTree) find(v interface{}) *rbNode {
+	x := t.root
+	for x != t.sentinel {
+		c := t.cmp(v, x.value)
+		switch {
+		case c < 0:
+			x = x.left
+		case c > 0:
+			x = x.right
+		default:
+			return x
+		}
+	}
+	return x
+}
+
+func (t *RedBlackTree) minimum(x *rbNode) *rbNode {
+	for x.left != t.sentinel {
+		x = x.left
+	}
+	return x
+}
+
+func (t *RedBlackTree) maximum(x *rbNode) *rbNode {
+	for x.right != t.sentinel {
+		x = x.right
+	}
+	return x
+}
+
+// successor returns the node that follows x in sorted order, or the sentinel.
+func (t *RedBlackTree) successor(x *rbNode) *rbNode {
+	if x.right != t.sentinel {
+		return t.minimum(x.right)
+	}
+	y := x.parent
+	for y != t.sentinel && x == y.right {
+		x, y = y, y.parent
+	}
+	return y
+}
+
+func (t *RedBlackTree) leftRotate(x *rbNode) {
+	y := x.right
+	x.right = y.left
+	if y.left != t.sentinel {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	switch {
+	case x.parent == t.sentinel:
+		t.root = y
+	case x == x.parent.left:
+		x.parent.left = y
+	default:
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+}
+
+func (t *RedBlackTree) rightRotate(x *rbNode) {
+	y := x.left
+	x.left = y.right
+	if y.right != t.sentinel {
+		y.right.parent = x
+	}
+	y.parent = x.parent
+	switch {
+	case x.parent == t.sentinel:
+		t.root = y
+	case x == x.parent.right:
+		x.parent.right = y
+	default:
+		x.parent.left = y
+	}
+	y.right = x
+	x.parent = y
+}
+
+// Add inserts v into the tree. Elements already stored are ignored.
+func (t *RedBlackTree) Add(v interface{}) {
+	y, x := t.sentinel, t.root
+	for x != t.sentinel {
+		y = x
+		c := t.cmp(v, x.value)
+		switch {
+		case c < 0:
+			x = x.left
+		case c > 0:
+			x = x.right
+		default:
+			return
+		}
+	}
+	z := &rbNode{value: v, left: t.sentinel, right: t.sentinel, parent: y, red: true}
+	switch {
+	case y == t.sentinel:
+		t.root = z
+	case t.cmp(v, y.value) < 0:
+		y.left = z
+	default:
+		y.right = z
+	}
+	t.length++
+	t.insertFixup(z)
+}
+
+// insertFixup restores the red-black properties after inserting z.
+func (t *RedBlackTree) insertFixup(z *rbNode) {
+	for z.parent.red {
+		if z.parent == z.parent.parent.left {
+			y := z.parent.parent.right
+			if y.red {
+				z.parent.red, y.red, z.parent.parent.red = false, false, true
+				z = z.parent.parent
+				continue
+			}
+			if z == z.parent.right {
+				z = z.parent
+				t.leftRotate(z)
+			}
+			z.parent.red, z.parent.parent.red = false, true
+			t.rightRotate(z.parent.parent)
+		} else {
+			y := z.parent.parent.left
+			if y.red {
+				z.parent.red, y.red, z.parent.parent.red = false, false, true
+				z = z.parent.parent
+				continue
+			}
+			if z == z.parent.left {
+				z = z.parent
+				t.rightRotate(z)
+			}
+			z.parent.red, z.parent.parent.red = false, true
+			t.leftRotate(z.parent.parent)
+		}
+	}
+	t.root.red = false
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v.
+func (t *RedBlackTree) transplant(u, v *rbNode) {
+	switch {
+	case u.parent == t.sentinel:
+		t.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	v.parent = u.parent
+}
+
+// Remove deletes v from the tree (if exists).
+func (t *RedBlackTree) Remove(v interface{}) {
+	z := t.find(v)
+	if z == t.sentinel {
+		return
+	}
+	var x *rbNode
+	y, yRed := z, z.red
+	switch {
+	case z.left == t.sentinel:
+		x = z.right
+		t.transplant(z, z.right)
+	case z.right == t.sentinel:
+		x = z.left
+		t.transplant(z, z.left)
+	default:
+		y = t.minimum(z.right)
+		yRed = y.red
+		x = y.right
+		if y.parent == z {
+			x.parent = y
+		} else {
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+	}
+	if !yRed {
+		t.deleteFixup(x)
+	}
+	t.length--
+	// Avoid memory leaks (free references for garbage collector)
+	z.left, z.right, z.parent, z.value = nil, nil, nil, nil
+	t.sentinel.parent = t.sentinel
+}
+
+// deleteFixup restores the red-black properties after removing a black node above x.
+func (t *RedBlackTree) deleteFixup(x *rbNode) {
+	for x != t.root && !x.red {
+		if x == x.parent.left {
+			w := x.parent.right
+			if w.red {
+				w.red, x.parent.red = false, true
+				t.leftRotate(x.parent)
+				w = x.parent.right
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = x.parent
+				continue
+			}
+			if !w.right.red {
+				w.left.red, w.red = false, true
+				t.rightRotate(w)
+				w = x.parent.right
+			}
+			w.red, x.parent.red, w.right.red = x.parent.red, false, false
+			t.leftRotate(x.parent)
+			x = t.root
+		} else {
+			w := x.parent.left
+			if w.red {
+				w.red, x.parent.red = false, true
+				t.rightRotate(x.parent)
+				w = x.parent.left
+			}
+			if !w.right.red && !w.left.red {
+				w.red = true
+				x = x.parent
+				continue
+			}
+			if !w.left.red {
+				w.right.red, w.red = false, true
+				t.leftRotate(w)
+				w = x.parent.left
+			}
+			w.red, x.parent.red, w.left.red = x.parent.red, false, false
+			t.rightRotate(x.parent)
+			x = t.root
+		}
+	}
+	x.red = false
+}
+
+// Contains returns whether an element is stored in the tree or not.
+func (t *RedBlackTree) Contains(v interface{}) bool {
+	return t.find(v) != t.sentinel
+}
+
+// Size returns the number of elements stored in the tree.
+func (t *RedBlackTree) Size() int {
+	return t.length
+}
+
+// Empty removes all elements from the tree.
+func (t *RedBlackTree) Empty() {
+	t.root = t.sentinel
+	t.length = 0
+}
+
+// Min returns the smallest element of the tree.
+func (t *RedBlackTree) Min() (interface{}, error) {
+	if t.root == t.sentinel {
+		return nil, fmt.Errorf("empty tree")
+	}
+	return t.minimum(t.root).value, nil
+}
+
+// Max returns the largest element of the tree.
+func (t *RedBlackTree) Max() (interface{}, error) {
+	if t.root == t.sentinel {
+		return nil, fmt.Errorf("empty tree")
+	}
+	return t.maximum(t.root).value, nil
+}
+
+// lowerBound returns the node holding the smallest element greater than (or equal to, if
+// inclusive) v, or the sentinel.
+func (t *RedBlackTree) lowerBound(v interface{}, inclusive bool) *rbNode {
+	best := t.sentinel
+	for x := t.root; x != t.sentinel; {
+		if c := t.cmp(x.value, v); c > 0 || (inclusive && c == 0) {
+			best = x
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	return best
+}
+
+// upperBound returns the node holding the largest element smaller than (or equal to, if
+// inclusive) v, or the sentinel.
+func (t *RedBlackTree) upperBound(v interface{}, inclusive bool) *rbNode {
+	best := t.sentinel
+	for x := t.root; x != t.sentinel; {
+		if c := t.cmp(x.value, v); c < 0 || (inclusive && c == 0) {
+			best = x
+			x = x.right
+		} else {
+			x = x.left
+		}
+	}
+	return best
+}
+
+// nodeValue returns the value of x or an error if x is the sentinel.
+func (t *RedBlackTree) nodeValue(x *rbNode, what string, v interface{}) (interface{}, error) {
+	if x == t.sentinel {
+		return nil, fmt.Errorf("%v has no %s in the tree", v, what)
+	}
+	return x.value, nil
+}
+
+// Floor returns the largest element smaller than or equal to v.
+func (t *RedBlackTree) Floor(v interface{}) (interface{}, error) {
+	return t.nodeValue(t.upperBound(v, true), "floor", v)
+}
+
+// Ceiling returns the smallest element greater than or equal to v.
+func (t *RedBlackTree) Ceiling(v interface{}) (interface{}, error) {
+	return t.nodeValue(t.lowerBound(v, true), "ceiling", v)
+}
+
+// Predecessor returns the largest element strictly smaller than v.
+func (t *RedBlackTree) Predecessor(v interface{}) (interface{}, error) {
+	return t.nodeValue(t.upperBound(v, false), "predecessor", v)
+}
+
+// Successor returns the smallest element strictly greater than v.
+func (t *RedBlackTree) Successor(v interface{}) (interface{}, error) {
+	return t.nodeValue(t.lowerBound(v, false), "successor", v)
+}
+
+// String returns a string representation of the tree content in sorted order.
+func (t *RedBlackTree) String() string {
+	var b strings.Builder
+	b.WriteString("[")
+	for x := t.minimum(t.root); x != t.sentinel; x = t.successor(x) {
+		if b.Len() > 1 {
+			b.WriteString(", ")
+		}
+		b.WriteString(fmt.Sprintf("%v", x.value))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Iterator returns an iterable over the elements of the tree in sorted order.
+func (t *RedBlackTree) Iterator() Iterable {
+	return &RedBlackTreeIterable{t: t, n: t.minimum(t.root)}
+}
+
+// Range returns an iterable over the elements in the closed range [lo, hi] in sorted order. A nil
+// bound means the range is unbounded on that side.
+func (t *RedBlackTree) Range(lo, hi interface{}) Iterable {
+	n := t.minimum(t.root)
+	if lo != nil {
+		n = t.lowerBound(lo, true)
+	}
+	return &RedBlackTreeIterable{t: t, n: n, hi: hi, bounded: hi != nil}
+}
+
+// RedBlackTreeIterable implements Iterable interface for RedBlackTree. Elements removed from the
+// tree ahead of the iterable are skipped, but if the element it's about to return is removed, the
+// iteration stops.
+type RedBlackTreeIterable struct {
+	t       *RedBlackTree
+	n       *rbNode
+	hi      interface{}
+	bounded bool
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *RedBlackTreeIterable) Scan() bool {
+	// Remove detaches the node, so its parent is nil rather than a node or the sentinel.
+	if i.n == i.t.sentinel || i.n.parent == nil {
+		return false
+	}
+	return !i.bounded || i.t.cmp(i.n.value, i.hi) <= 0
+}
+
+// Next returns the next element in the iterable.
+func (i *RedBlackTreeIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	v := i.n.value
+	i.n = i.t.successor(i.n)
+	return v, nil
+}
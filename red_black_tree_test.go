@@ -0,0 +1,243 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// checkRedBlackInvariants verifies the binary search tree order, the red-black properties, the
+// parent links and the stored size of the tree.
+func checkRedBlackInvariants(t *testing.T, tree *RedBlackTree) {
+	t.Helper()
+	if tree.root.red {
+		t.Fatal("root is red")
+	}
+	if tree.sentinel.red {
+		t.Fatal("sentinel is red")
+	}
+	count := 0
+	// walk returns the black height of the subtree rooted at x.
+	var walk func(x *rbNode, lo, hi interface{}) int
+	walk = func(x *rbNode, lo, hi interface{}) int {
+		if x == tree.sentinel {
+			return 1
+		}
+		count++
+		if lo != nil && tree.cmp(x.value, lo) <= 0 || hi != nil && tree.cmp(x.value, hi) >= 0 {
+			t.Fatalf("node %v breaks the search order within (%v, %v)", x.value, lo, hi)
+		}
+		for _, c := range []*rbNode{x.left, x.right} {
+			if c != tree.sentinel && c.parent != x {
+				t.Fatalf("child %v of %v has a wrong parent link", c.value, x.value)
+			}
+			if x.red && c.red {
+				t.Fatalf("red node %v has a red child %v", x.value, c.value)
+			}
+		}
+		lh, rh := walk(x.left, lo, x.value), walk(x.right, x.value, hi)
+		if lh != rh {
+			t.Fatalf("node %v has black heights %d and %d", x.value, lh, rh)
+		}
+		if !x.red {
+			lh++
+		}
+		return lh
+	}
+	walk(tree.root, nil, nil)
+	if count != tree.Size() {
+		t.Fatalf("Size(): %d, tree has %d nodes", tree.Size(), count)
+	}
+}
+
+// iterableToSlice consumes an iterable and returns its elements.
+func iterableToSlice(t *testing.T, it Iterable) []interface{} {
+	t.Helper()
+	values := []interface{}{}
+	for it.Scan() {
+		v, err := it.Next()
+		if err != nil {
+			t.Fatalf("Next() returned unexpected error; %v", err)
+		}
+		values = append(values, v)
+	}
+	if _, err := it.Next(); err == nil {
+		t.Fatal("Next() on exhausted iterable returned nil error, want error")
+	}
+	return values
+}
+
+// sortedKeys returns the keys of a reference set in increasing order.
+func sortedKeys(set map[int]bool) []interface{} {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = k
+	}
+	return values
+}
+
+func TestRedBlackTree_ThroughOps(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		t.Run(fmt.Sprintf("n = %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			tree := NewRedBlackTree(IntComparator)
+			control := map[int]bool{}
+			for i := 0; i < 4*n; i++ {
+				v := r.Intn(2 * n)
+				if r.Intn(3) == 0 {
+					tree.Remove(v)
+					delete(control, v)
+				} else {
+					tree.Add(v)
+					control[v] = true
+				}
+				checkRedBlackInvariants(t, tree)
+				if got := tree.Contains(v); got != control[v] {
+					t.Fatalf("Contains(%d): %v, want %v", v, got, control[v])
+				}
+			}
+			if diff := cmp.Diff(sortedKeys(control), iterableToSlice(t, tree.Iterator())); diff != "" {
+				t.Fatalf("Iterator() produced unwanted elements (-want +got):\n%s", diff)
+			}
+			for v := range control {
+				tree.Remove(v)
+				checkRedBlackInvariants(t, tree)
+			}
+			if tree.Size() != 0 {
+				t.Errorf("Size(): %d after removing every element", tree.Size())
+			}
+		})
+	}
+}
+
+func TestRedBlackTree_OrderQueries(t *testing.T) {
+	tree := NewRedBlackTree(IntComparator)
+	for _, v := range []int{50, 20, 80, 10, 30, 70, 90, 60} {
+		tree.Add(v)
+	}
+	tests := []struct {
+		name string
+		f    func(interface{}) (interface{}, error)
+		in   []int
+		want []interface{}
+	}{
+		{name: "Floor", f: tree.Floor, in: []int{5, 10, 15, 55, 90, 95}, want: []interface{}{nil, 10, 10, 50, 90, 90}},
+		{name: "Ceiling", f: tree.Ceiling, in: []int{5, 10, 15, 55, 90, 95}, want: []interface{}{10, 10, 20, 60, 90, nil}},
+		{name: "Predecessor", f: tree.Predecessor, in: []int{5, 10, 15, 50, 90, 95}, want: []interface{}{nil, nil, 10, 30, 80, 90}},
+		{name: "Successor", f: tree.Successor, in: []int{5, 10, 15, 50, 90, 95}, want: []interface{}{10, 20, 20, 60, nil, nil}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i, v := range test.in {
+				got, err := test.f(v)
+				if test.want[i] == nil {
+					if err == nil {
+						t.Errorf("%s(%d) returned nil error, want error", test.name, v)
+					}
+					continue
+				}
+				if err != nil || got != test.want[i] {
+					t.Errorf("%s(%d): %v, %v, want %v, nil", test.name, v, got, err, test.want[i])
+				}
+			}
+		})
+	}
+	if min, err := tree.Min(); err != nil || min != 10 {
+		t.Errorf("Min(): %v, %v, want 10, nil", min, err)
+	}
+	if max, err := tree.Max(); err != nil || max != 90 {
+		t.Errorf("Max(): %v, %v, want 90, nil", max, err)
+	}
+	if got := tree.String(); got != "[10, 20, 30, 50, 60, 70, 80, 90]" {
+		t.Errorf("String(): %s", got)
+	}
+	tree.Empty()
+	if _, err := tree.Min(); err == nil {
+		t.Error("Min() on empty tree returned nil error, want error")
+	}
+	if _, err := tree.Max(); err == nil {
+		t.Error("Max() on empty tree returned nil error, want error")
+	}
+	if tree.Size() != 0 || tree.Contains(10) {
+		t.Errorf("Empty() left elements in the tree: %s", tree)
+	}
+}
+
+func TestRedBlackTree_Range(t *testing.T) {
+	tree := NewRedBlackTree(IntComparator)
+	for v := 0; v < 100; v += 5 {
+		tree.Add(v)
+	}
+	tests := []struct {
+		lo, hi int
+		want   []interface{}
+	}{
+		{lo: 10, hi: 25, want: []interface{}{10, 15, 20, 25}},
+		{lo: 11, hi: 24, want: []interface{}{15, 20}},
+		{lo: -10, hi: 3, want: []interface{}{0}},
+		{lo: 93, hi: 200, want: []interface{}{95}},
+		{lo: 11, hi: 14, want: []interface{}{}},
+		{lo: 30, hi: 20, want: []interface{}{}},
+	}
+	for _, test := range tests {
+		got := iterableToSlice(t, tree.Range(test.lo, test.hi))
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Range(%d, %d) produced unwanted elements (-want +got):\n%s", test.lo, test.hi, diff)
+		}
+	}
+}
+
+func TestRedBlackTree_RemoveWhileIterating(t *testing.T) {
+	tree := NewRedBlackTree(IntComparator)
+	for v := 0; v < 10; v++ {
+		tree.Add(v)
+	}
+	it := tree.Iterator()
+	var got []interface{}
+	for it.Scan() {
+		v, err := it.Next()
+		if err != nil {
+			t.Fatalf("Next() returned unexpected error; %v", err)
+		}
+		got = append(got, v)
+		switch v {
+		case 2:
+			// Removing elements ahead of the iterable skips them.
+			tree.Remove(5)
+			tree.Remove(0)
+		case 6:
+			// Removing the element the iterable is about to return stops the iteration.
+			tree.Remove(7)
+		}
+	}
+	if diff := cmp.Diff([]interface{}{0, 1, 2, 3, 4, 6}, got); diff != "" {
+		t.Errorf("iteration produced unwanted elements (-want +got):\n%s", diff)
+	}
+	if _, err := it.Next(); err == nil {
+		t.Error("Next() after the iteration stopped returned nil error, want error")
+	}
+	want := []interface{}{1, 2, 3, 4, 6, 8, 9}
+	if diff := cmp.Diff(want, iterableToSlice(t, tree.Iterator())); diff != "" {
+		t.Errorf("Iterator() produced unwanted elements (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedBlackTree_StringComparator(t *testing.T) {
+	tree := NewRedBlackTree(StringComparator)
+	for _, w := range []string{"pear", "apple", "fig", "banana", "apple"} {
+		tree.Add(w)
+		checkRedBlackInvariants(t, tree)
+	}
+	want := []interface{}{"apple", "banana", "fig", "pear"}
+	if diff := cmp.Diff(want, iterableToSlice(t, tree.Iterator())); diff != "" {
+		t.Errorf("Iterator() produced unwanted elements (-want +got):\n%s", diff)
+	}
+}
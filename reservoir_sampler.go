@@ -0,0 +1,51 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ReservoirSampler keeps a uniform random sample of k values from a stream of unknown length.
+// After n values, each of them is in the sample with probability k/n.
+type ReservoirSampler struct {
+	k      int
+	sample []interface{}
+	seen   int
+	r      *rand.Rand
+}
+
+// NewReservoirSampler returns an empty sampler of k values. Samplers with the same seed pick the
+// same positions of their streams.
+func NewReservoirSampler(k int, seed int64) (*ReservoirSampler, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("sample size must be positive, got %d", k)
+	}
+	return &ReservoirSampler{
+		k:      k,
+		sample: make([]interface{}, 0, k),
+		r:      rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// Add offers the next value of the stream. Once the reservoir is full, the n-th value replaces a
+// random sampled one with probability k/n (Algorithm R).
+func (s *ReservoirSampler) Add(v interface{}) {
+	s.seen++
+	if len(s.sample) < s.k {
+		s.sample = append(s.sample, v)
+		return
+	}
+	if j := s.r.Intn(s.seen); j < s.k {
+		s.sample[j] = v
+	}
+}
+
+// Sample returns the sampled values, at most k of them, in no particular order.
+func (s *ReservoirSampler) Sample() []interface{} {
+	return append([]interface{}(nil), s.sample...)
+}
+
+// Seen returns the number of values offered so far.
+func (s *ReservoirSampler) Seen() int {
+	return s.seen
+}
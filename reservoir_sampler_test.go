@@ -0,0 +1,54 @@
+package ads
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReservoirSampler(t *testing.T) {
+	if _, err := NewReservoirSampler(0, 1); err == nil {
+		t.Error("NewReservoirSampler(0) returned nil error, want error")
+	}
+	s, _ := NewReservoirSampler(5, 1)
+	for i := 0; i < 3; i++ {
+		s.Add(i)
+	}
+	if diff := cmp.Diff([]interface{}{0, 1, 2}, s.Sample()); diff != "" {
+		t.Errorf("Sample() of a short stream mismatch (-want +got):\n%s", diff)
+	}
+	for i := 3; i < 100; i++ {
+		s.Add(i)
+	}
+	if len(s.Sample()) != 5 || s.Seen() != 100 {
+		t.Errorf("Sample() has %d values after %d, want 5 after 100", len(s.Sample()), s.Seen())
+	}
+	same, _ := NewReservoirSampler(5, 1)
+	for i := 0; i < 100; i++ {
+		same.Add(i)
+	}
+	if diff := cmp.Diff(s.Sample(), same.Sample()); diff != "" {
+		t.Errorf("samplers with the same seed disagree (-first +second):\n%s", diff)
+	}
+}
+
+func TestReservoirSampler_Uniform(t *testing.T) {
+	const n, k, trials = 20, 5, 20000
+	freq := make([]int, n)
+	for seed := int64(0); seed < trials; seed++ {
+		s, _ := NewReservoirSampler(k, seed)
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+		for _, v := range s.Sample() {
+			freq[v.(int)]++
+		}
+	}
+	// Each value is expected in k/n of the samples.
+	want := float64(trials) * k / n
+	for v, f := range freq {
+		if float64(f) < 0.9*want || float64(f) > 1.1*want {
+			t.Errorf("value %d sampled %d times, want about %v", v, f, want)
+		}
+	}
+}
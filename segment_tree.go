@@ -0,0 +1,357 @@
+package ads
+
+import "fmt"
+
+// Monoid describes an associative operation over integers with an identity element. Pow and
+// Shift are optional and enable range assignment and range addition on a SegmentTree.
+type Monoid struct {
+	// Combine two aggregates, the left one covering the elements before the right one.
+	Combine func(a, b int) int
+	// Identity element, Combine(Identity, a) = Combine(a, Identity) = a.
+	Identity int
+	// Pow returns the aggregate of n elements all equal to v.
+	Pow func(v, n int) int
+	// Shift returns the aggregate of n elements after adding d to each of them, given their
+	// current aggregate.
+	Shift func(agg, d, n int) int
+}
+
+// SumMonoid aggregates elements by addition.
+var SumMonoid = Monoid{
+	Combine:  func(a, b int) int { return a + b },
+	Identity: 0,
+	Pow:      func(v, n int) int { return v * n },
+	Shift:    func(agg, d, n int) int { return agg + d*n },
+}
+
+// MinMonoid aggregates elements by their minimum.
+var MinMonoid = Monoid{
+	Combine: func(a, b int) int {
+		if b < a {
+			return b
+		}
+		return a
+	},
+	Identity: maxInt,
+	Pow:      func(v, n int) int { return v },
+	Shift: func(agg, d, n int) int {
+		if agg == maxInt {
+			return agg
+		}
+		return agg + d
+	},
+}
+
+// MaxMonoid aggregates elements by their maximum.
+var MaxMonoid = Monoid{
+	Combine: func(a, b int) int {
+		if b > a {
+			return b
+		}
+		return a
+	},
+	Identity: minInt,
+	Pow:      func(v, n int) int { return v },
+	Shift: func(agg, d, n int) int {
+		if agg == minInt {
+			return agg
+		}
+		return agg + d
+	},
+}
+
+// GCDMonoid aggregates elements by their greatest common divisor. Range addition is not
+// supported since the gcd of the shifted elements can't be derived from the current one.
+var GCDMonoid = Monoid{
+	Combine: func(a, b int) int {
+		if a < 0 {
+			a = -a
+		}
+		if b < 0 {
+			b = -b
+		}
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	},
+	Identity: 0,
+	Pow: func(v, n int) int {
+		if v < 0 {
+			return -v
+		}
+		return v
+	},
+}
+
+// segmentTreeTag is a pending update of a segment tree node: an optional assignment followed by
+// an addition.
+type segmentTreeTag struct {
+	assign    bool
+	assignVal int
+	add       int
+}
+
+// SegmentTree computes aggregates of arbitrary ranges under a Monoid, supporting point updates
+// and lazily propagated range assignment and range addition.
+// Important: this implementation is one-indexed.
+type SegmentTree struct {
+	m    Monoid
+	agg  []int
+	tag  []segmentTreeTag
+	size []int
+	n    int
+}
+
+// NewSegmentTree returns a segment tree of the given size with every element set to the monoid
+// identity.
+func NewSegmentTree(m Monoid, size int) *SegmentTree {
+	values := make([]int, size)
+	for i := range values {
+		values[i] = m.Identity
+	}
+	return NewSegmentTreeFrom(m, values)
+}
+
+// NewSegmentTreeFrom returns a segment tree holding the given values, values[0] being stored at
+// index 1. The tree is built in linear time.
+func NewSegmentTreeFrom(m Monoid, values []int) *SegmentTree {
+	n := len(values)
+	t := &SegmentTree{m: m, n: n, agg: make([]int, 4*n+1), tag: make([]segmentTreeTag, 4*n+1),
+		size: make([]int, 4*n+1)}
+	if n > 0 {
+		t.build(1, 1, n, values)
+	}
+	return t
+}
+
+func (t *SegmentTree) build(x, l, r int, values []int) {
+	t.size[x] = r - l + 1
+	if l == r {
+		t.agg[x] = values[l-1]
+		return
+	}
+	mid := (l + r) / 2
+	t.build(2*x, l, mid, values)
+	t.build(2*x+1, mid+1, r, values)
+	t.agg[x] = t.m.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+// apply a pending update to node x and record it so it's pushed down to its children later.
+func (t *SegmentTree) apply(x int, u segmentTreeTag) {
+	if u.assign {
+		t.agg[x] = t.m.Pow(u.assignVal, t.size[x])
+		t.tag[x] = segmentTreeTag{assign: true, assignVal: u.assignVal}
+	}
+	if u.add == 0 {
+		return
+	}
+	if t.tag[x].assign {
+		// An addition after an assignment is just a different assignment.
+		t.tag[x].assignVal += u.add
+		t.agg[x] = t.m.Pow(t.tag[x].assignVal, t.size[x])
+		return
+	}
+	t.agg[x] = t.m.Shift(t.agg[x], u.add, t.size[x])
+	t.tag[x].add += u.add
+}
+
+// push propagates the pending update of node x to its children.
+func (t *SegmentTree) push(x int) {
+	if t.tag[x] != (segmentTreeTag{}) {
+		t.apply(2*x, t.tag[x])
+		t.apply(2*x+1, t.tag[x])
+		t.tag[x] = segmentTreeTag{}
+	}
+}
+
+func (t *SegmentTree) update(x, nl, nr, l, r int, u segmentTreeTag) {
+	if r < nl || nr < l {
+		return
+	}
+	if l <= nl && nr <= r {
+		t.apply(x, u)
+		return
+	}
+	t.push(x)
+	mid := (nl + nr) / 2
+	t.update(2*x, nl, mid, l, r, u)
+	t.update(2*x+1, mid+1, nr, l, r, u)
+	t.agg[x] = t.m.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+func (t *SegmentTree) query(x, nl, nr, l, r int) int {
+	if r < nl || nr < l {
+		return t.m.Identity
+	}
+	if l <= nl && nr <= r {
+		return t.agg[x]
+	}
+	t.push(x)
+	mid := (nl + nr) / 2
+	return t.m.Combine(t.query(2*x, nl, mid, l, r), t.query(2*x+1, mid+1, nr, l, r))
+}
+
+func (t *SegmentTree) validRange(l, r int) error {
+	if l <= 0 || r > t.n || l > r {
+		return fmt.Errorf("tree size is %d, got invalid range [%d, %d]", t.n, l, r)
+	}
+	return nil
+}
+
+// Query returns the aggregate of the elements in the closed range [l, r].
+func (t *SegmentTree) Query(l, r int) (int, error) {
+	if err := t.validRange(l, r); err != nil {
+		return 0, err
+	}
+	return t.query(1, 1, t.n, l, r), nil
+}
+
+// Get returns the element stored at the given index.
+func (t *SegmentTree) Get(i int) (int, error) {
+	if i <= 0 || i > t.n {
+		return 0, fmt.Errorf("invalid index %d", i)
+	}
+	return t.query(1, 1, t.n, i, i), nil
+}
+
+// Set the element stored at the given index.
+func (t *SegmentTree) Set(i, value int) error {
+	if i <= 0 || i > t.n {
+		return fmt.Errorf("invalid index %d", i)
+	}
+	t.setLeaf(1, 1, t.n, i, value)
+	return nil
+}
+
+func (t *SegmentTree) setLeaf(x, nl, nr, i, value int) {
+	if nl == nr {
+		t.agg[x] = value
+		t.tag[x] = segmentTreeTag{}
+		return
+	}
+	t.push(x)
+	mid := (nl + nr) / 2
+	if i <= mid {
+		t.setLeaf(2*x, nl, mid, i, value)
+	} else {
+		t.setLeaf(2*x+1, mid+1, nr, i, value)
+	}
+	t.agg[x] = t.m.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+// RangeAssign sets every element in the closed range [l, r] to value. The monoid must define Pow.
+func (t *SegmentTree) RangeAssign(l, r, value int) error {
+	if t.m.Pow == nil {
+		return fmt.Errorf("monoid does not support range assignment")
+	}
+	if err := t.validRange(l, r); err != nil {
+		return err
+	}
+	t.update(1, 1, t.n, l, r, segmentTreeTag{assign: true, assignVal: value})
+	return nil
+}
+
+// RangeAdd adds value to every element in the closed range [l, r]. The monoid must define Shift
+// and Pow.
+func (t *SegmentTree) RangeAdd(l, r, value int) error {
+	if t.m.Pow == nil || t.m.Shift == nil {
+		return fmt.Errorf("monoid does not support range addition")
+	}
+	if err := t.validRange(l, r); err != nil {
+		return err
+	}
+	t.update(1, 1, t.n, l, r, segmentTreeTag{add: value})
+	return nil
+}
+
+// MaxRight returns the largest r in [l-1, n] such that pred(Query(l, r)) holds, the aggregate of
+// an empty range being the identity. pred must hold for the identity and be monotone: once it
+// fails for a range it fails for every range that extends it.
+func (t *SegmentTree) MaxRight(l int, pred func(int) bool) (int, error) {
+	if l <= 0 || l > t.n+1 {
+		return 0, fmt.Errorf("invalid index %d", l)
+	}
+	if !pred(t.m.Identity) {
+		return 0, fmt.Errorf("predicate must hold for the identity")
+	}
+	if l == t.n+1 {
+		return t.n, nil
+	}
+	acc := t.m.Identity
+	if p := t.maxRight(1, 1, t.n, l, pred, &acc); p != -1 {
+		return p - 1, nil
+	}
+	return t.n, nil
+}
+
+// maxRight returns the first index at or after l where pred fails, or -1 if it never does within
+// the node.
+func (t *SegmentTree) maxRight(x, nl, nr, l int, pred func(int) bool, acc *int) int {
+	if nr < l {
+		return -1
+	}
+	if nl >= l {
+		if c := t.m.Combine(*acc, t.agg[x]); pred(c) {
+			*acc = c
+			return -1
+		}
+		if nl == nr {
+			return nl
+		}
+	}
+	t.push(x)
+	mid := (nl + nr) / 2
+	if p := t.maxRight(2*x, nl, mid, l, pred, acc); p != -1 {
+		return p
+	}
+	return t.maxRight(2*x+1, mid+1, nr, l, pred, acc)
+}
+
+// MinLeft returns the smallest l in [1, r+1] such that pred(Query(l, r)) holds, the aggregate of
+// an empty range being the identity. pred must hold for the identity and be monotone: once it
+// fails for a range it fails for every range that extends it.
+func (t *SegmentTree) MinLeft(r int, pred func(int) bool) (int, error) {
+	if r < 0 || r > t.n {
+		return 0, fmt.Errorf("invalid index %d", r)
+	}
+	if !pred(t.m.Identity) {
+		return 0, fmt.Errorf("predicate must hold for the identity")
+	}
+	if r == 0 {
+		return 1, nil
+	}
+	acc := t.m.Identity
+	if p := t.minLeft(1, 1, t.n, r, pred, &acc); p != -1 {
+		return p + 1, nil
+	}
+	return 1, nil
+}
+
+// minLeft returns the last index at or before r where pred fails, or -1 if it never does within
+// the node.
+func (t *SegmentTree) minLeft(x, nl, nr, r int, pred func(int) bool, acc *int) int {
+	if nl > r {
+		return -1
+	}
+	if nr <= r {
+		if c := t.m.Combine(t.agg[x], *acc); pred(c) {
+			*acc = c
+			return -1
+		}
+		if nl == nr {
+			return nl
+		}
+	}
+	t.push(x)
+	mid := (nl + nr) / 2
+	if p := t.minLeft(2*x+1, mid+1, nr, r, pred, acc); p != -1 {
+		return p
+	}
+	return t.minLeft(2*x, nl, mid, r, pred, acc)
+}
+
+// Size returns the number of elements in the tree.
+func (t *SegmentTree) Size() int {
+	return t.n
+}
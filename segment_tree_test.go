@@ -0,0 +1,198 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentTree_CrossCheckFenwickTree(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 100} {
+		t.Run(fmt.Sprintf("size %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = r.Intn(201) - 100
+			}
+			st := NewSegmentTreeFrom(SumMonoid, values)
+			fwt := NewRangeFenwickTree(n)
+			for i, v := range values {
+				fwt.Add(i+1, v)
+			}
+			for k := 0; k < 5*n; k++ {
+				l := r.Intn(n) + 1
+				rr := l + r.Intn(n-l+1)
+				v := r.Intn(201) - 100
+				switch k % 3 {
+				case 0:
+					if err := st.RangeAdd(l, rr, v); err != nil {
+						t.Fatalf("RangeAdd(%d, %d, %d) returned unexpected error; %v", l, rr, v, err)
+					}
+					fwt.AddRange(l, rr, v)
+				case 1:
+					old, _ := fwt.PointGet(l)
+					if err := st.Set(l, v); err != nil {
+						t.Fatalf("Set(%d, %d) returned unexpected error; %v", l, v, err)
+					}
+					fwt.Add(l, v-old)
+				case 2:
+					if err := st.RangeAssign(l, rr, v); err != nil {
+						t.Fatalf("RangeAssign(%d, %d, %d) returned unexpected error; %v", l, rr, v, err)
+					}
+					for i := l; i <= rr; i++ {
+						old, _ := fwt.PointGet(i)
+						fwt.Add(i, v-old)
+					}
+				}
+				l = r.Intn(n) + 1
+				rr = l + r.Intn(n-l+1)
+				want, _ := fwt.RangeSum(l, rr)
+				got, err := st.Query(l, rr)
+				if err != nil {
+					t.Fatalf("Query(%d, %d) returned unexpected error; %v", l, rr, err)
+				}
+				if got != want {
+					t.Fatalf("Query(%d, %d): %d, want %d", l, rr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentTree_Monoids(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         Monoid
+		canAdd    bool
+		maxValue  int
+		predicate func(agg int) bool
+	}{
+		{name: "min", m: MinMonoid, canAdd: true, maxValue: 1000, predicate: func(agg int) bool { return agg >= 100 }},
+		{name: "max", m: MaxMonoid, canAdd: true, maxValue: 1000, predicate: func(agg int) bool { return agg <= 900 }},
+		{name: "gcd", m: GCDMonoid, maxValue: 60, predicate: func(agg int) bool { return agg == 0 || agg%4 == 0 }},
+		{name: "sum", m: SumMonoid, canAdd: true, maxValue: 10, predicate: func(agg int) bool { return agg <= 50 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			const n = 64
+			r := rand.New(rand.NewSource(1))
+			control := make([]int, n+1)
+			for i := 1; i <= n; i++ {
+				control[i] = r.Intn(test.maxValue) + 1
+			}
+			st := NewSegmentTreeFrom(test.m, control[1:])
+			aggregate := func(l, r int) int {
+				agg := test.m.Identity
+				for i := l; i <= r; i++ {
+					agg = test.m.Combine(agg, control[i])
+				}
+				return agg
+			}
+			for k := 0; k < 500; k++ {
+				l := r.Intn(n) + 1
+				rr := l + r.Intn(n-l+1)
+				switch k % 4 {
+				case 0:
+					v := r.Intn(test.maxValue) + 1
+					st.RangeAssign(l, rr, v)
+					for i := l; i <= rr; i++ {
+						control[i] = v
+					}
+				case 1:
+					if !test.canAdd {
+						if err := st.RangeAdd(l, rr, 1); err == nil {
+							t.Fatal("RangeAdd() returned nil error, want error")
+						}
+						continue
+					}
+					// Keep values positive so sum predicates remain monotone.
+					v := r.Intn(3)
+					st.RangeAdd(l, rr, v)
+					for i := l; i <= rr; i++ {
+						control[i] += v
+					}
+				case 2:
+					v := r.Intn(test.maxValue) + 1
+					st.Set(l, v)
+					control[l] = v
+				}
+				for l := 1; l <= n; l++ {
+					got, err := st.Get(l)
+					if err != nil || got != control[l] {
+						t.Fatalf("Get(%d): %d, %v, want %d, nil", l, got, err, control[l])
+					}
+				}
+				if got, _ := st.Query(l, rr); got != aggregate(l, rr) {
+					t.Fatalf("Query(%d, %d): %d, want %d", l, rr, got, aggregate(l, rr))
+				}
+				wantRight := l - 1
+				for wantRight < n && test.predicate(aggregate(l, wantRight+1)) {
+					wantRight++
+				}
+				if got, err := st.MaxRight(l, test.predicate); err != nil || got != wantRight {
+					t.Fatalf("MaxRight(%d): %d, %v, want %d, nil", l, got, err, wantRight)
+				}
+				wantLeft := rr + 1
+				for wantLeft > 1 && test.predicate(aggregate(wantLeft-1, rr)) {
+					wantLeft--
+				}
+				if got, err := st.MinLeft(rr, test.predicate); err != nil || got != wantLeft {
+					t.Fatalf("MinLeft(%d): %d, %v, want %d, nil", rr, got, err, wantLeft)
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentTree_Errors(t *testing.T) {
+	st := NewSegmentTree(SumMonoid, 10)
+	always := func(int) bool { return true }
+	for _, i := range []int{0, 11} {
+		if _, err := st.Get(i); err == nil {
+			t.Errorf("Get(%d) returned nil error, want error", i)
+		}
+		if err := st.Set(i, 1); err == nil {
+			t.Errorf("Set(%d, 1) returned nil error, want error", i)
+		}
+	}
+	for _, rng := range [][2]int{{0, 5}, {1, 11}, {6, 5}} {
+		if _, err := st.Query(rng[0], rng[1]); err == nil {
+			t.Errorf("Query(%d, %d) returned nil error, want error", rng[0], rng[1])
+		}
+		if err := st.RangeAdd(rng[0], rng[1], 1); err == nil {
+			t.Errorf("RangeAdd(%d, %d, 1) returned nil error, want error", rng[0], rng[1])
+		}
+		if err := st.RangeAssign(rng[0], rng[1], 1); err == nil {
+			t.Errorf("RangeAssign(%d, %d, 1) returned nil error, want error", rng[0], rng[1])
+		}
+	}
+	if _, err := st.MaxRight(0, always); err == nil {
+		t.Error("MaxRight(0) returned nil error, want error")
+	}
+	if _, err := st.MaxRight(12, always); err == nil {
+		t.Error("MaxRight(12) returned nil error, want error")
+	}
+	if _, err := st.MinLeft(-1, always); err == nil {
+		t.Error("MinLeft(-1) returned nil error, want error")
+	}
+	if _, err := st.MinLeft(11, always); err == nil {
+		t.Error("MinLeft(11) returned nil error, want error")
+	}
+	never := func(int) bool { return false }
+	if _, err := st.MaxRight(1, never); err == nil {
+		t.Error("MaxRight() with predicate failing on identity returned nil error, want error")
+	}
+	if _, err := st.MinLeft(10, never); err == nil {
+		t.Error("MinLeft() with predicate failing on identity returned nil error, want error")
+	}
+	if got, err := st.MaxRight(11, always); err != nil || got != 10 {
+		t.Errorf("MaxRight(11): %d, %v, want 10, nil", got, err)
+	}
+	if got, err := st.MinLeft(0, always); err != nil || got != 1 {
+		t.Errorf("MinLeft(0): %d, %v, want 1, nil", got, err)
+	}
+	noAssign := Monoid{Combine: SumMonoid.Combine}
+	if err := NewSegmentTree(noAssign, 10).RangeAssign(1, 2, 1); err == nil {
+		t.Error("RangeAssign() without Pow returned nil error, want error")
+	}
+}
@@ -0,0 +1,286 @@
+package ads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CycleError is returned by graph algorithms that can't handle the cycle found in the graph. The
+// cycle is reported as its vertices in order.
+type CycleError struct {
+	Cycle []int
+	// kind describes why the cycle is a problem, e.g. "negative cycle".
+	kind string
+}
+
+// Error returns the error string representation.
+func (e *CycleError) Error() string {
+	vs := make([]string, len(e.Cycle)+1)
+	for i, v := range e.Cycle {
+		vs[i] = fmt.Sprintf("%d", v)
+	}
+	vs[len(e.Cycle)] = vs[0]
+	return fmt.Sprintf("graph has a %s: %s", e.kind, strings.Join(vs, " -> "))
+}
+
+// reversePath reverses the vertices of a path in place.
+func reversePath(path []int) []int {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// ShortestPaths is the result of a single-source shortest path search: the distance from the
+// source to every reached vertex and the predecessor of each vertex on its shortest path.
+type ShortestPaths struct {
+	source  int
+	dist    []int
+	pred    []int
+	reached []bool
+}
+
+func newShortestPaths(n, src int) *ShortestPaths {
+	p := &ShortestPaths{
+		source:  src,
+		dist:    make([]int, n),
+		pred:    make([]int, n),
+		reached: make([]bool, n),
+	}
+	for v := range p.pred {
+		p.pred[v] = -1
+	}
+	p.reached[src] = true
+	return p
+}
+
+// Source returns the vertex where the paths start.
+func (p *ShortestPaths) Source() int {
+	return p.source
+}
+
+// DistanceTo returns the length of the shortest path from the source to v. Returns 0, false if v
+// is unreachable.
+func (p *ShortestPaths) DistanceTo(v int) (int, bool) {
+	if v < 0 || v >= len(p.dist) || !p.reached[v] {
+		return 0, false
+	}
+	return p.dist[v], true
+}
+
+// Predecessor returns the vertex before v on its shortest path. Returns -1, false if v is the
+// source or is unreachable.
+func (p *ShortestPaths) Predecessor(v int) (int, bool) {
+	if v < 0 || v >= len(p.pred) || p.pred[v] == -1 {
+		return -1, false
+	}
+	return p.pred[v], true
+}
+
+// PathTo returns the vertices of the shortest path from the source to v.
+func (p *ShortestPaths) PathTo(v int) ([]int, error) {
+	if _, ok := p.DistanceTo(v); !ok {
+		return nil, fmt.Errorf("vertex %d is unreachable from %d", v, p.source)
+	}
+	var path []int
+	for ; v != -1; v = p.pred[v] {
+		path = append(path, v)
+	}
+	return reversePath(path), nil
+}
+
+// validateNonNegative returns an error if the graph has a negative edge.
+func (g *Graph) validateNonNegative() error {
+	for it := g.Edges(); it.Scan(); {
+		if e := graphNextEdge(it); e.Weight < 0 {
+			return fmt.Errorf("edge %d -> %d has negative weight %d", e.From, e.To, e.Weight)
+		}
+	}
+	return nil
+}
+
+// Dijkstra computes the shortest paths from src to every vertex. Edge weights must be
+// non-negative. Vertices are settled in order of distance using a binary heap with decrease-key,
+// so it takes O((n + m) log n) time.
+func (g *Graph) Dijkstra(src int) (*ShortestPaths, error) {
+	if err := g.validate(src); err != nil {
+		return nil, err
+	}
+	if err := g.validateNonNegative(); err != nil {
+		return nil, err
+	}
+	return g.bestFirst(src, -1, func(int) int { return 0 }), nil
+}
+
+// AStar computes the shortest path from src to dst, exploring first the vertices with the
+// smallest distance from src plus estimated distance to dst given by the heuristic. Edge weights
+// must be non-negative, and the heuristic must never overestimate the distance to dst (be
+// admissible) for the path to be the shortest. The result only holds the paths to the vertices
+// settled before reaching dst.
+func (g *Graph) AStar(src, dst int, heuristic func(v int) int) (*ShortestPaths, error) {
+	if err := g.validate(src, dst); err != nil {
+		return nil, err
+	}
+	if err := g.validateNonNegative(); err != nil {
+		return nil, err
+	}
+	return g.bestFirst(src, dst, heuristic), nil
+}
+
+// bestFirst runs A* from src until dst (or every vertex, if dst is -1) is settled. Vertices are
+// reopened when a shorter path is found after settling them, which only happens when the
+// heuristic is admissible but inconsistent.
+func (g *Graph) bestFirst(src, dst int, heuristic func(v int) int) *ShortestPaths {
+	p := newShortestPaths(len(g.adj), src)
+	h, _ := NewDaryHeap(2)
+	items := make([]*HeapItem, len(g.adj))
+	inHeap := make([]bool, len(g.adj))
+	items[src], inHeap[src] = h.Insert(heuristic(src), src), true
+	for h.Size() > 0 {
+		item, _ := h.DeleteMin()
+		u := item.Value.(int)
+		inHeap[u] = false
+		if u == dst {
+			break
+		}
+		for it := g.adj[u].Iterator(); it.Scan(); {
+			e := graphNextEdge(it)
+			d := p.dist[u] + e.Weight
+			if p.reached[e.To] && d >= p.dist[e.To] {
+				continue
+			}
+			p.dist[e.To], p.pred[e.To], p.reached[e.To] = d, u, true
+			if inHeap[e.To] {
+				h.DecreaseKey(items[e.To], d+heuristic(e.To))
+			} else {
+				items[e.To], inHeap[e.To] = h.Insert(d+heuristic(e.To), e.To), true
+			}
+		}
+	}
+	return p
+}
+
+// BellmanFord computes the shortest paths from src to every vertex, allowing negative weights. It
+// returns a *CycleError with a negative cycle reachable from src if there's one, since shortest
+// paths aren't defined then. It takes O(nm) time.
+func (g *Graph) BellmanFord(src int) (*ShortestPaths, error) {
+	if err := g.validate(src); err != nil {
+		return nil, err
+	}
+	p := newShortestPaths(len(g.adj), src)
+	// relax runs a pass over every edge and returns the last improved vertex, or -1.
+	relax := func() int {
+		last := -1
+		for u := range g.adj {
+			if !p.reached[u] {
+				continue
+			}
+			for it := g.adj[u].Iterator(); it.Scan(); {
+				e := graphNextEdge(it)
+				if d := p.dist[u] + e.Weight; !p.reached[e.To] || d < p.dist[e.To] {
+					p.dist[e.To], p.pred[e.To], p.reached[e.To] = d, u, true
+					last = e.To
+				}
+			}
+		}
+		return last
+	}
+	// Shortest paths have at most n-1 edges, so an improvement in the n-th pass means a cycle.
+	for i := 0; i < len(g.adj)-1; i++ {
+		if relax() == -1 {
+			return p, nil
+		}
+	}
+	v := relax()
+	if v == -1 {
+		return p, nil
+	}
+	// The predecessor chain of v leads into the cycle after at most n steps.
+	for i := 0; i < len(g.adj); i++ {
+		v = p.pred[v]
+	}
+	cycle := []int{v}
+	for u := p.pred[v]; u != v; u = p.pred[u] {
+		cycle = append(cycle, u)
+	}
+	return nil, &CycleError{Cycle: reversePath(cycle), kind: "negative cycle"}
+}
+
+// AllPairsShortestPaths is the result of an all-pairs shortest path search.
+type AllPairsShortestPaths struct {
+	dist [][]int
+	// pred[u][v] is the vertex before v on the shortest path from u to v, -1 if there's none.
+	pred [][]int
+}
+
+// DistanceBetween returns the length of the shortest path from u to v. Returns 0, false if v is
+// unreachable from u.
+func (p *AllPairsShortestPaths) DistanceBetween(u, v int) (int, bool) {
+	n := len(p.dist)
+	if u < 0 || u >= n || v < 0 || v >= n || (u != v && p.pred[u][v] == -1) {
+		return 0, false
+	}
+	return p.dist[u][v], true
+}
+
+// PathBetween returns the vertices of the shortest path from u to v.
+func (p *AllPairsShortestPaths) PathBetween(u, v int) ([]int, error) {
+	if _, ok := p.DistanceBetween(u, v); !ok {
+		return nil, fmt.Errorf("vertex %d is unreachable from %d", v, u)
+	}
+	path := []int{v}
+	for ; v != u; v = p.pred[u][v] {
+		path = append(path, p.pred[u][v])
+	}
+	return reversePath(path), nil
+}
+
+// FloydWarshall computes the shortest paths between every pair of vertices in O(n³) time,
+// allowing negative weights. It returns a *CycleError if the graph has a negative cycle.
+func (g *Graph) FloydWarshall() (*AllPairsShortestPaths, error) {
+	n := len(g.adj)
+	p := &AllPairsShortestPaths{dist: make([][]int, n), pred: make([][]int, n)}
+	for u := 0; u < n; u++ {
+		p.dist[u], p.pred[u] = make([]int, n), make([]int, n)
+		for v := range p.pred[u] {
+			p.pred[u][v] = -1
+		}
+		for it := g.adj[u].Iterator(); it.Scan(); {
+			e := graphNextEdge(it)
+			if e.To == u && e.Weight >= 0 {
+				continue // The empty path is shorter
+			}
+			if p.pred[u][e.To] == -1 || e.Weight < p.dist[u][e.To] {
+				p.dist[u][e.To], p.pred[u][e.To] = e.Weight, u
+			}
+		}
+	}
+	// reached tells whether there's a path from u to v, the empty one included.
+	reached := func(u, v int) bool {
+		return u == v || p.pred[u][v] != -1
+	}
+	for k := 0; k < n; k++ {
+		for u := 0; u < n; u++ {
+			if !reached(u, k) {
+				continue
+			}
+			for v := 0; v < n; v++ {
+				if !reached(k, v) {
+					continue
+				}
+				if d := p.dist[u][k] + p.dist[k][v]; !reached(u, v) || d < p.dist[u][v] {
+					p.dist[u][v], p.pred[u][v] = d, p.pred[k][v]
+				}
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		if p.dist[v][v] < 0 {
+			// Once a negative cycle is relaxed the predecessors of v no longer form a path, so the
+			// cycle is found again with BellmanFord, which always reaches one from v.
+			_, err := g.BellmanFord(v)
+			return nil, err
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,290 @@
+package ads
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newWeightedTestGraph returns a graph of n vertices with the given (from, to, weight) edges.
+func newWeightedTestGraph(t *testing.T, n int, flags GraphFlags, edges [][3]int) *Graph {
+	t.Helper()
+	g := NewGraph(n, flags|Weighted)
+	for _, e := range edges {
+		if err := g.AddWeightedEdge(e[0], e[1], e[2]); err != nil {
+			t.Fatalf("AddWeightedEdge(%v) returned unexpected error; %v", e, err)
+		}
+	}
+	return g
+}
+
+// checkShortestPaths verifies the distances of p and that every path to a reached vertex uses
+// existing edges adding up to its distance.
+func checkShortestPaths(t *testing.T, g *Graph, p *ShortestPaths, want map[int]int) {
+	t.Helper()
+	for v := 0; v < g.Order(); v++ {
+		wantDist, wantOk := want[v]
+		d, ok := p.DistanceTo(v)
+		if ok != wantOk || d != wantDist {
+			t.Fatalf("DistanceTo(%d): %d, %v, want %d, %v", v, d, ok, wantDist, wantOk)
+		}
+		path, err := p.PathTo(v)
+		if !ok {
+			if err == nil {
+				t.Fatalf("PathTo(%d) returned nil error for an unreachable vertex, want error", v)
+			}
+			continue
+		}
+		if err != nil || path[0] != p.Source() || path[len(path)-1] != v {
+			t.Fatalf("PathTo(%d): %v, %v, want a path from %d", v, path, err, p.Source())
+		}
+		if pred, ok := p.Predecessor(v); ok != (len(path) > 1) || (ok && pred != path[len(path)-2]) {
+			t.Fatalf("Predecessor(%d): %d, %v doesn't match path %v", v, pred, ok, path)
+		}
+		if w := pathWeight(t, g, path); w != d {
+			t.Fatalf("PathTo(%d): %v weighs %d, want %d", v, path, w, d)
+		}
+	}
+}
+
+// pathWeight returns the weight of a path using the lightest edge between consecutive vertices.
+func pathWeight(t *testing.T, g *Graph, path []int) int {
+	t.Helper()
+	total := 0
+	for i := 1; i < len(path); i++ {
+		best, found := 0, false
+		it, _ := g.Neighbors(path[i-1])
+		for it.Scan() {
+			if e := graphNextEdge(it); e.To == path[i] && (!found || e.Weight < best) {
+				best, found = e.Weight, true
+			}
+		}
+		if !found {
+			t.Fatalf("path %v uses a missing edge %d -> %d", path, path[i-1], path[i])
+		}
+		total += best
+	}
+	return total
+}
+
+func TestGraph_Dijkstra(t *testing.T) {
+	// CLRS, figure 24.6: s=0, t=1, x=2, y=3, z=4.
+	g := newWeightedTestGraph(t, 6, Directed, [][3]int{
+		{0, 1, 10}, {0, 3, 5}, {1, 2, 1}, {1, 3, 2}, {2, 4, 4},
+		{3, 1, 3}, {3, 2, 9}, {3, 4, 2}, {4, 0, 7}, {4, 2, 6},
+	})
+	p, err := g.Dijkstra(0)
+	if err != nil {
+		t.Fatalf("Dijkstra(0) returned unexpected error; %v", err)
+	}
+	checkShortestPaths(t, g, p, map[int]int{0: 0, 1: 8, 2: 9, 3: 5, 4: 7})
+	if path, _ := p.PathTo(2); !cmp.Equal(path, []int{0, 3, 1, 2}) {
+		t.Errorf("PathTo(2): %v, want [0 3 1 2]", path)
+	}
+	g.AddWeightedEdge(5, 0, -1)
+	if _, err := g.Dijkstra(0); err == nil {
+		t.Error("Dijkstra() with a negative edge returned nil error, want error")
+	}
+	if _, err := g.Dijkstra(6); err == nil {
+		t.Error("Dijkstra(6) returned nil error, want error")
+	}
+}
+
+func TestGraph_BellmanFord(t *testing.T) {
+	// CLRS, figure 24.4: s=0, t=1, x=2, y=3, z=4.
+	edges := [][3]int{
+		{0, 1, 6}, {0, 3, 7}, {1, 2, 5}, {1, 3, 8}, {1, 4, -4},
+		{2, 1, -2}, {3, 2, -3}, {3, 4, 9}, {4, 0, 2}, {4, 2, 7},
+	}
+	g := newWeightedTestGraph(t, 6, Directed, edges)
+	p, err := g.BellmanFord(0)
+	if err != nil {
+		t.Fatalf("BellmanFord(0) returned unexpected error; %v", err)
+	}
+	checkShortestPaths(t, g, p, map[int]int{0: 0, 1: 2, 2: 4, 3: 7, 4: -2})
+
+	// A negative cycle unreachable from the source doesn't matter.
+	g.AddVertex()
+	g.AddWeightedEdge(5, 6, -1)
+	g.AddWeightedEdge(6, 5, -1)
+	if _, err := g.BellmanFord(0); err != nil {
+		t.Fatalf("BellmanFord(0) returned unexpected error; %v", err)
+	}
+	g.AddWeightedEdge(2, 5, 0)
+	_, err = g.BellmanFord(0)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("BellmanFord(0) returned %v, want a *CycleError", err)
+	}
+	checkCycle(t, g, cycleErr.Cycle)
+	if w := pathWeight(t, g, append(cycleErr.Cycle, cycleErr.Cycle[0])); w >= 0 {
+		t.Errorf("cycle %v weighs %d, want a negative cycle", cycleErr.Cycle, w)
+	}
+	if got, want := cycleErr.Error(), "graph has a negative cycle: "; !strings.HasPrefix(got, want) {
+		t.Errorf("Error(): %q, want prefix %q", got, want)
+	}
+
+	// Negative undirected edges are negative cycles.
+	u := newWeightedTestGraph(t, 2, 0, [][3]int{{0, 1, -1}})
+	if _, err := u.BellmanFord(1); !errors.As(err, &cycleErr) {
+		t.Errorf("BellmanFord(1) on a negative undirected edge returned %v, want a *CycleError", err)
+	}
+}
+
+func TestGraph_AStar(t *testing.T) {
+	// 10x10 grid where vertex r*10+c is connected to its right and lower neighbors, with a wall in
+	// column 5 except for row 9.
+	const size = 10
+	g := NewGraph(size*size, Weighted)
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			if c == 5 && r != size-1 {
+				continue
+			}
+			if c+1 < size && !(c+1 == 5 && r != size-1) {
+				g.AddWeightedEdge(r*size+c, r*size+c+1, 1)
+			}
+			if r+1 < size && !(c == 5 && r+1 != size-1) {
+				g.AddWeightedEdge(r*size+c, (r+1)*size+c, 1)
+			}
+		}
+	}
+	manhattan := func(dst int) func(int) int {
+		return func(v int) int {
+			dr, dc := v/size-dst/size, v%size-dst%size
+			if dr < 0 {
+				dr = -dr
+			}
+			if dc < 0 {
+				dc = -dc
+			}
+			return dr + dc
+		}
+	}
+	src, dst := 0, 9
+	p, err := g.AStar(src, dst, manhattan(dst))
+	if err != nil {
+		t.Fatalf("AStar() returned unexpected error; %v", err)
+	}
+	if d, ok := p.DistanceTo(dst); !ok || d != 27 {
+		t.Errorf("DistanceTo(%d): %d, %v, want 27, true", dst, d, ok)
+	}
+	path, _ := p.PathTo(dst)
+	if len(path) != 28 || pathWeight(t, g, path) != 27 {
+		t.Errorf("PathTo(%d): %v, want a path of 27 edges", dst, path)
+	}
+	if _, err := g.AStar(0, size*size, manhattan(dst)); err == nil {
+		t.Error("AStar() with an invalid destination returned nil error, want error")
+	}
+}
+
+func TestGraph_FloydWarshall(t *testing.T) {
+	// CLRS, figure 25.1 (vertices shifted down by one).
+	g := newWeightedTestGraph(t, 6, Directed, [][3]int{
+		{0, 1, 3}, {0, 2, 8}, {0, 4, -4}, {1, 3, 1}, {1, 4, 7},
+		{2, 1, 4}, {3, 0, 2}, {3, 2, -5}, {4, 3, 6}, {5, 5, 3},
+	})
+	p, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatalf("FloydWarshall() returned unexpected error; %v", err)
+	}
+	want := [][]int{
+		{0, 1, -3, 2, -4},
+		{3, 0, -4, 1, -1},
+		{7, 4, 0, 5, 3},
+		{2, -1, -5, 0, -2},
+		{8, 5, 1, 6, 0},
+	}
+	for u := range want {
+		for v := range want[u] {
+			if d, ok := p.DistanceBetween(u, v); !ok || d != want[u][v] {
+				t.Errorf("DistanceBetween(%d, %d): %d, %v, want %d, true", u, v, d, ok, want[u][v])
+			}
+		}
+	}
+	if path, _ := p.PathBetween(0, 2); !cmp.Equal(path, []int{0, 4, 3, 2}) {
+		t.Errorf("PathBetween(0, 2): %v, want [0 4 3 2]", path)
+	}
+	if d, ok := p.DistanceBetween(5, 5); !ok || d != 0 {
+		t.Errorf("DistanceBetween(5, 5): %d, %v, want 0, true", d, ok)
+	}
+	if _, ok := p.DistanceBetween(0, 5); ok {
+		t.Error("DistanceBetween(0, 5) returned true for an unreachable vertex")
+	}
+	if _, err := p.PathBetween(5, 0); err == nil {
+		t.Error("PathBetween(5, 0) returned nil error for an unreachable vertex, want error")
+	}
+	// 0 -> 4 -> 3 -> 2 -> 0 weighs -2.
+	g.AddWeightedEdge(2, 0, 1)
+	_, err = g.FloydWarshall()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("FloydWarshall() returned %v, want a *CycleError", err)
+	}
+	checkCycle(t, g, cycleErr.Cycle)
+	if w := pathWeight(t, g, append(cycleErr.Cycle, cycleErr.Cycle[0])); w >= 0 {
+		t.Errorf("cycle %v weighs %d, want a negative cycle", cycleErr.Cycle, w)
+	}
+
+	// Random graphs with negative edges usually have negative cycles.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(15)
+		g := NewGraph(n, Directed|Weighted)
+		for m := r.Intn(3 * n); m > 0; m-- {
+			g.AddWeightedEdge(r.Intn(n), r.Intn(n), r.Intn(20)-8)
+		}
+		if _, err := g.FloydWarshall(); errors.As(err, &cycleErr) {
+			checkCycle(t, g, cycleErr.Cycle)
+			if w := pathWeight(t, g, append(cycleErr.Cycle, cycleErr.Cycle[0])); w >= 0 {
+				t.Errorf("cycle %v weighs %d, want a negative cycle", cycleErr.Cycle, w)
+			}
+		} else if err != nil {
+			t.Fatalf("FloydWarshall() returned %v, want a *CycleError", err)
+		}
+	}
+}
+
+func TestGraph_ShortestPathsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(25)
+		flags := Directed
+		if r.Intn(2) == 0 {
+			flags = 0
+		}
+		g := randomGraph(r, n, r.Intn(4*n), 20, flags)
+		all, err := g.FloydWarshall()
+		if err != nil {
+			t.Fatalf("FloydWarshall() returned unexpected error; %v", err)
+		}
+		for src := 0; src < n; src++ {
+			want := map[int]int{}
+			for v := 0; v < n; v++ {
+				if d, ok := all.DistanceBetween(src, v); ok {
+					want[v] = d
+				}
+				if path, err := all.PathBetween(src, v); err == nil && pathWeight(t, g, path) != want[v] {
+					t.Fatalf("PathBetween(%d, %d): %v, want a path of weight %d", src, v, path, want[v])
+				}
+			}
+			dijkstra, _ := g.Dijkstra(src)
+			checkShortestPaths(t, g, dijkstra, want)
+			bellmanFord, err := g.BellmanFord(src)
+			if err != nil {
+				t.Fatalf("BellmanFord(%d) returned unexpected error; %v", src, err)
+			}
+			checkShortestPaths(t, g, bellmanFord, want)
+			dst := r.Intn(n)
+			// Half the true distance is an admissible (but inconsistent) heuristic.
+			aStar, _ := g.AStar(src, dst, func(v int) int { return all.dist[v][dst] / 2 })
+			d, ok := aStar.DistanceTo(dst)
+			if wantDist, wantOk := want[dst]; ok != wantOk || d != wantDist {
+				t.Fatalf("AStar(%d, %d): %d, %v, want %d, %v", src, dst, d, ok, wantDist, wantOk)
+			}
+		}
+	}
+}
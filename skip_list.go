@@ -0,0 +1,207 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// validateSkipListParams returns an error if the skip list parameters are out of range.
+func validateSkipListParams(maxLevel int, p float64) error {
+	if maxLevel < 1 {
+		return fmt.Errorf("max level must be at least 1, got %d", maxLevel)
+	}
+	if p <= 0 || p >= 1 {
+		return fmt.Errorf("probability must be in (0, 1), got %v", p)
+	}
+	return nil
+}
+
+// skipListNode holds an entry and its forward references, one per level the node belongs to.
+type skipListNode struct {
+	key, value interface{}
+	next       []*skipListNode
+}
+
+// SkipList is a probabilistic ordered map. Each entry is promoted to the next level with
+// probability p, so searches skip over most of the entries in expected O(log n) time.
+type SkipList struct {
+	// head is a sentinel node with maxLevel forward references.
+	head     *skipListNode
+	cmp      Comparator
+	p        float64
+	rng      *rand.Rand
+	maxLevel int
+	// level is the number of levels currently in use.
+	level  int
+	length int
+}
+
+// NewSkipList returns an empty skip list ordered by the given comparator. Entries are promoted
+// with probability p up to maxLevel levels, using a random source initialized with seed.
+func NewSkipList(cmp Comparator, maxLevel int, p float64, seed int64) (*SkipList, error) {
+	if err := validateSkipListParams(maxLevel, p); err != nil {
+		return nil, err
+	}
+	return &SkipList{
+		head:     &skipListNode{next: make([]*skipListNode, maxLevel)},
+		cmp:      cmp,
+		p:        p,
+		rng:      rand.New(rand.NewSource(seed)),
+		maxLevel: maxLevel,
+		level:    1,
+	}, nil
+}
+
+// randomLevel returns the number of levels of a new node.
+func (s *SkipList) randomLevel() int {
+	l := 1
+	for l < s.maxLevel && s.rng.Float64() < s.p {
+		l++
+	}
+	return l
+}
+
+// findPredecessors returns, for every level, the last node whose key is smaller than k.
+func (s *SkipList) findPredecessors(k interface{}) []*skipListNode {
+	preds := make([]*skipListNode, s.maxLevel)
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, k) < 0 {
+			x = x.next[i]
+		}
+		preds[i] = x
+	}
+	for i := s.level; i < s.maxLevel; i++ {
+		preds[i] = s.head
+	}
+	return preds
+}
+
+// lowerBound returns the first node whose key is greater than or equal to k, or nil.
+func (s *SkipList) lowerBound(k interface{}) *skipListNode {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, k) < 0 {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// Get the value stored in the given key. Returns nil, false if it doesn't exist.
+func (s *SkipList) Get(k interface{}) (interface{}, bool) {
+	if x := s.lowerBound(k); x != nil && s.cmp(x.key, k) == 0 {
+		return x.value, true
+	}
+	return nil, false
+}
+
+// Set or update a value using given key.
+func (s *SkipList) Set(k, v interface{}) {
+	preds := s.findPredecessors(k)
+	if x := preds[0].next[0]; x != nil && s.cmp(x.key, k) == 0 {
+		x.value = v
+		return
+	}
+	l := s.randomLevel()
+	if l > s.level {
+		s.level = l
+	}
+	x := &skipListNode{key: k, value: v, next: make([]*skipListNode, l)}
+	for i := 0; i < l; i++ {
+		x.next[i] = preds[i].next[i]
+		preds[i].next[i] = x
+	}
+	s.length++
+}
+
+// Remove the entry stored at the given key (if exists).
+func (s *SkipList) Remove(k interface{}) {
+	preds := s.findPredecessors(k)
+	x := preds[0].next[0]
+	if x == nil || s.cmp(x.key, k) != 0 {
+		return
+	}
+	for i := range x.next {
+		preds[i].next[i] = x.next[i]
+		// Avoid memory leaks (free references for garbage collector)
+		x.next[i] = nil
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+}
+
+// Floor returns the entry with the largest key smaller than or equal to k.
+func (s *SkipList) Floor(k interface{}) (MapEntry, error) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, k) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x == s.head {
+		return MapEntry{}, fmt.Errorf("%v has no floor in the skip list", k)
+	}
+	return MapEntry{Key: x.key, Value: x.value}, nil
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to k.
+func (s *SkipList) Ceiling(k interface{}) (MapEntry, error) {
+	x := s.lowerBound(k)
+	if x == nil {
+		return MapEntry{}, fmt.Errorf("%v has no ceiling in the skip list", k)
+	}
+	return MapEntry{Key: x.key, Value: x.value}, nil
+}
+
+// Size returns the number of entries stored in the skip list.
+func (s *SkipList) Size() int {
+	return s.length
+}
+
+// Empty removes all entries from the skip list.
+func (s *SkipList) Empty() {
+	s.head = &skipListNode{next: make([]*skipListNode, s.maxLevel)}
+	s.level = 1
+	s.length = 0
+}
+
+// Iterator returns an iterable over the entries of the skip list in key order.
+func (s *SkipList) Iterator() Iterable {
+	return &SkipListIterable{s: s, n: s.head.next[0]}
+}
+
+// Range returns an iterable over the entries with keys in the closed range [lo, hi] in key order.
+// A nil bound means the range is unbounded on that side.
+func (s *SkipList) Range(lo, hi interface{}) Iterable {
+	n := s.head.next[0]
+	if lo != nil {
+		n = s.lowerBound(lo)
+	}
+	return &SkipListIterable{s: s, n: n, hi: hi, bounded: hi != nil}
+}
+
+// SkipListIterable implements Iterable interface for SkipList, returning MapEntry elements.
+type SkipListIterable struct {
+	s       *SkipList
+	n       *skipListNode
+	hi      interface{}
+	bounded bool
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *SkipListIterable) Scan() bool {
+	return i.n != nil && (!i.bounded || i.s.cmp(i.n.key, i.hi) <= 0)
+}
+
+// Next returns the next element in the iterable.
+func (i *SkipListIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	e := MapEntry{Key: i.n.key, Value: i.n.value}
+	i.n = i.n.next[0]
+	return e, nil
+}
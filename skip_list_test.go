@@ -0,0 +1,168 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// checkSkipListInvariants verifies that every level is sorted and is a subsequence of the level
+// below it.
+func checkSkipListInvariants(t *testing.T, s *SkipList) {
+	t.Helper()
+	count := 0
+	for x := s.head.next[0]; x != nil; x = x.next[0] {
+		count++
+		if x.next[0] != nil && s.cmp(x.key, x.next[0].key) >= 0 {
+			t.Fatalf("keys %v and %v are not sorted", x.key, x.next[0].key)
+		}
+	}
+	if count != s.Size() {
+		t.Fatalf("Size(): %d, bottom level has %d entries", s.Size(), count)
+	}
+	for i := 1; i < s.maxLevel; i++ {
+		below := s.head.next[i-1]
+		for x := s.head.next[i]; x != nil; x = x.next[i] {
+			if i >= s.level {
+				t.Fatalf("level %d is in use, but list level is %d", i, s.level)
+			}
+			for below != nil && below != x {
+				below = below.next[i-1]
+			}
+			if below == nil {
+				t.Fatalf("node %v at level %d is missing from level %d", x.key, i, i-1)
+			}
+		}
+	}
+}
+
+func TestNewSkipList_Errors(t *testing.T) {
+	tests := []struct {
+		maxLevel int
+		p        float64
+	}{
+		{maxLevel: 0, p: 0.5}, {maxLevel: -1, p: 0.5},
+		{maxLevel: 16, p: 0}, {maxLevel: 16, p: 1}, {maxLevel: 16, p: -0.5},
+	}
+	for _, test := range tests {
+		if _, err := NewSkipList(IntComparator, test.maxLevel, test.p, 1); err == nil {
+			t.Errorf("NewSkipList(%d, %v) returned nil error, want error", test.maxLevel, test.p)
+		}
+		if _, err := NewConcurrentSkipList(IntComparator, test.maxLevel, test.p, 1); err == nil {
+			t.Errorf("NewConcurrentSkipList(%d, %v) returned nil error, want error", test.maxLevel, test.p)
+		}
+	}
+}
+
+func TestSkipList_ThroughOps(t *testing.T) {
+	params := []struct {
+		maxLevel int
+		p        float64
+	}{{1, 0.5}, {4, 0.5}, {16, 0.25}, {32, 0.5}}
+	for _, param := range params {
+		t.Run(fmt.Sprintf("max level %d p %v", param.maxLevel, param.p), func(t *testing.T) {
+			const n = 500
+			r := rand.New(rand.NewSource(1))
+			s, err := NewSkipList(IntComparator, param.maxLevel, param.p, 1)
+			if err != nil {
+				t.Fatalf("NewSkipList() returned unexpected error; %v", err)
+			}
+			control := map[int]int{}
+			for i := 0; i < 4*n; i++ {
+				k := r.Intn(n)
+				if r.Intn(3) == 0 {
+					s.Remove(k)
+					delete(control, k)
+				} else {
+					s.Set(k, i)
+					control[k] = i
+				}
+				checkSkipListInvariants(t, s)
+				got, ok := s.Get(k)
+				want, wantOk := control[k]
+				if ok != wantOk || (ok && got != want) {
+					t.Fatalf("Get(%d): %v, %v, want %v, %v", k, got, ok, want, wantOk)
+				}
+			}
+			want := []interface{}{}
+			for k := 0; k < n; k++ {
+				if v, ok := control[k]; ok {
+					want = append(want, MapEntry{Key: k, Value: v})
+				}
+			}
+			if diff := cmp.Diff(want, iterableToSlice(t, s.Iterator())); diff != "" {
+				t.Fatalf("Iterator() produced unwanted entries (-want +got):\n%s", diff)
+			}
+			s.Empty()
+			if s.Size() != 0 || s.Iterator().Scan() {
+				t.Error("Empty() left entries in the skip list")
+			}
+		})
+	}
+}
+
+func TestSkipList_Deterministic(t *testing.T) {
+	levels := func() []int {
+		s, _ := NewSkipList(IntComparator, 16, 0.5, 42)
+		for i := 0; i < 100; i++ {
+			s.Set(i, nil)
+		}
+		var l []int
+		for x := s.head.next[0]; x != nil; x = x.next[0] {
+			l = append(l, len(x.next))
+		}
+		return l
+	}
+	if diff := cmp.Diff(levels(), levels()); diff != "" {
+		t.Errorf("skip lists with the same seed have different levels (-first +second):\n%s", diff)
+	}
+}
+
+func TestSkipList_OrderQueries(t *testing.T) {
+	s, _ := NewSkipList(IntComparator, 8, 0.5, 1)
+	for k := 0; k < 100; k += 10 {
+		s.Set(k, -k)
+	}
+	for k, want := range map[int]int{0: 0, 5: 0, 10: 10, 99: 90, 1000: 90} {
+		if got, err := s.Floor(k); err != nil || got != (MapEntry{Key: want, Value: -want}) {
+			t.Errorf("Floor(%d): %v, %v, want %d, nil", k, got, err, want)
+		}
+	}
+	for k, want := range map[int]int{-5: 0, 0: 0, 5: 10, 90: 90, 81: 90} {
+		if got, err := s.Ceiling(k); err != nil || got != (MapEntry{Key: want, Value: -want}) {
+			t.Errorf("Ceiling(%d): %v, %v, want %d, nil", k, got, err, want)
+		}
+	}
+	if _, err := s.Floor(-1); err == nil {
+		t.Error("Floor(-1) returned nil error, want error")
+	}
+	if _, err := s.Ceiling(91); err == nil {
+		t.Error("Ceiling(91) returned nil error, want error")
+	}
+	tests := []struct {
+		lo, hi interface{}
+		want   []int
+	}{
+		{lo: 10, hi: 30, want: []int{10, 20, 30}},
+		{lo: 11, hi: 29, want: []int{20}},
+		{lo: -10, hi: 0, want: []int{0}},
+		{lo: 85, hi: 200, want: []int{90}},
+		{lo: 91, hi: 200, want: []int{}},
+		{lo: 30, hi: 20, want: []int{}},
+		// Nil bounds leave the range open on that side.
+		{lo: nil, hi: 15, want: []int{0, 10}},
+		{lo: 75, hi: nil, want: []int{80, 90}},
+		{lo: nil, hi: -1, want: []int{}},
+	}
+	for _, test := range tests {
+		want := []interface{}{}
+		for _, k := range test.want {
+			want = append(want, MapEntry{Key: k, Value: -k})
+		}
+		if diff := cmp.Diff(want, iterableToSlice(t, s.Range(test.lo, test.hi))); diff != "" {
+			t.Errorf("Range(%v, %v) produced unwanted entries (-want +got):\n%s", test.lo, test.hi, diff)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// validateUndirected returns an error if the graph is directed.
+func (g *Graph) validateUndirected() error {
+	if g.Directed() {
+		return fmt.Errorf("graph must be undirected")
+	}
+	return nil
+}
+
+// graphEdges returns every edge of the graph, as reported by Edges.
+func (g *Graph) graphEdges() []Edge {
+	edges := make([]Edge, 0, g.edges)
+	for it := g.Edges(); it.Scan(); {
+		edges = append(edges, graphNextEdge(it))
+	}
+	return edges
+}
+
+// Kruskal returns the edges of a minimum spanning forest of an undirected graph and their total
+// weight. Edges are taken by increasing weight when they join two different trees, which are
+// tracked with a DisjointSet. It takes O(m log m) time.
+func (g *Graph) Kruskal() ([]Edge, int, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, 0, err
+	}
+	edges := g.graphEdges()
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+	ds := NewDisjointSet(len(g.adj))
+	var forest []Edge
+	weight := 0
+	for _, e := range edges {
+		if merged, _ := ds.Union(e.From, e.To); merged {
+			forest = append(forest, e)
+			weight += e.Weight
+		}
+	}
+	return forest, weight, nil
+}
+
+// Prim returns the edges of a minimum spanning forest of an undirected graph and their total
+// weight. Each tree grows from its smallest vertex by taking the lightest edge leaving it, found
+// with a binary heap keyed by the weight connecting each vertex to the tree. It takes
+// O((n + m) log n) time.
+func (g *Graph) Prim() ([]Edge, int, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, 0, err
+	}
+	n := len(g.adj)
+	inTree := make([]bool, n)
+	// best is the lightest edge connecting each vertex in the heap to the tree.
+	best := make([]Edge, n)
+	items := make([]*HeapItem, n)
+	h, _ := NewDaryHeap(2)
+	var forest []Edge
+	weight := 0
+	for root := 0; root < n; root++ {
+		if inTree[root] {
+			continue
+		}
+		items[root] = h.Insert(0, root)
+		for h.Size() > 0 {
+			item, _ := h.DeleteMin()
+			u := item.Value.(int)
+			inTree[u] = true
+			if u != root {
+				forest = append(forest, best[u])
+				weight += best[u].Weight
+			}
+			for it := g.adj[u].Iterator(); it.Scan(); {
+				e := graphNextEdge(it)
+				switch v := e.To; {
+				case inTree[v]:
+				case items[v] == nil:
+					best[v], items[v] = e, h.Insert(e.Weight, v)
+				case e.Weight < items[v].Key:
+					best[v] = e
+					h.DecreaseKey(items[v], e.Weight)
+				}
+			}
+		}
+	}
+	return forest, weight, nil
+}
+
+// Boruvka returns the edges of a minimum spanning forest of an undirected graph and their total
+// weight. In each round every tree takes the lightest edge leaving it, which at least halves the
+// number of trees, so it takes O(m log n) time. Ties are broken by edge position so trees never
+// pick edges closing a cycle.
+func (g *Graph) Boruvka() ([]Edge, int, error) {
+	if err := g.validateUndirected(); err != nil {
+		return nil, 0, err
+	}
+	edges := g.graphEdges()
+	// lighter compares edges by weight and then by position.
+	lighter := func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight || (edges[i].Weight == edges[j].Weight && i < j)
+	}
+	ds := NewDisjointSet(len(g.adj))
+	cheapest := make([]int, len(g.adj))
+	var forest []Edge
+	weight := 0
+	for {
+		for v := range cheapest {
+			cheapest[v] = -1
+		}
+		for i, e := range edges {
+			ru, rv := ds.find(e.From), ds.find(e.To)
+			if ru == rv {
+				continue
+			}
+			if cheapest[ru] == -1 || lighter(i, cheapest[ru]) {
+				cheapest[ru] = i
+			}
+			if cheapest[rv] == -1 || lighter(i, cheapest[rv]) {
+				cheapest[rv] = i
+			}
+		}
+		merged := false
+		for _, i := range cheapest {
+			if i == -1 {
+				continue
+			}
+			// Two trees may pick the same edge, only the first one merges them.
+			if ok, _ := ds.Union(edges[i].From, edges[i].To); ok {
+				forest = append(forest, edges[i])
+				weight += edges[i].Weight
+				merged = true
+			}
+		}
+		if !merged {
+			return forest, weight, nil
+		}
+	}
+}
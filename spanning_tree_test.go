@@ -0,0 +1,90 @@
+package ads
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// mstAlgorithms lists every minimum spanning forest implementation.
+var mstAlgorithms = []struct {
+	name string
+	run  func(g *Graph) ([]Edge, int, error)
+}{
+	{name: "kruskal", run: (*Graph).Kruskal},
+	{name: "prim", run: (*Graph).Prim},
+	{name: "boruvka", run: (*Graph).Boruvka},
+}
+
+// checkSpanningForest verifies that forest is made of edges of g, weighs weight and connects each
+// component of g without cycles.
+func checkSpanningForest(t *testing.T, g *Graph, forest []Edge, weight int) {
+	t.Helper()
+	_, components := g.ConnectedComponents()
+	if len(forest) != g.Order()-components {
+		t.Fatalf("forest has %d edges, want %d", len(forest), g.Order()-components)
+	}
+	ds := NewDisjointSet(g.Order())
+	total := 0
+	for _, e := range forest {
+		if ok, _ := g.HasEdge(e.From, e.To); !ok {
+			t.Fatalf("forest uses a missing edge %v", e)
+		}
+		if merged, _ := ds.Union(e.From, e.To); !merged {
+			t.Fatalf("edge %v closes a cycle in the forest", e)
+		}
+		total += e.Weight
+	}
+	if total != weight {
+		t.Fatalf("forest weighs %d, reported %d", total, weight)
+	}
+}
+
+func TestGraph_MinimumSpanningTree(t *testing.T) {
+	// CLRS, figure 23.1: a=0, b=1, ..., i=8.
+	g := newWeightedTestGraph(t, 9, 0, [][3]int{
+		{0, 1, 4}, {0, 7, 8}, {1, 2, 8}, {1, 7, 11}, {2, 3, 7}, {2, 5, 4}, {2, 8, 2},
+		{3, 4, 9}, {3, 5, 14}, {4, 5, 10}, {5, 6, 2}, {6, 7, 1}, {6, 8, 6}, {7, 8, 7},
+	})
+	for _, a := range mstAlgorithms {
+		t.Run(a.name, func(t *testing.T) {
+			forest, weight, err := a.run(g)
+			if err != nil {
+				t.Fatalf("returned unexpected error; %v", err)
+			}
+			if weight != 37 {
+				t.Errorf("weight: %d, want 37", weight)
+			}
+			checkSpanningForest(t, g, forest, weight)
+			if _, _, err := a.run(NewGraph(2, Directed)); err == nil {
+				t.Error("returned nil error for a directed graph, want error")
+			}
+		})
+	}
+}
+
+func TestGraph_MinimumSpanningTreesAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(30)
+		// Few distinct weights (some negative) produce many ties.
+		g := randomGraph(r, n, r.Intn(4*n), 5, 0)
+		for it := g.Edges(); it.Scan(); {
+			if e := graphNextEdge(it); r.Intn(4) == 0 {
+				g.AddWeightedEdge(e.From, e.To, -e.Weight)
+			}
+		}
+		want := 0
+		for j, a := range mstAlgorithms {
+			forest, weight, err := a.run(g)
+			if err != nil {
+				t.Fatalf("%s returned unexpected error; %v", a.name, err)
+			}
+			checkSpanningForest(t, g, forest, weight)
+			if j == 0 {
+				want = weight
+			} else if weight != want {
+				t.Fatalf("%s forest weighs %d, %s forest weighs %d", a.name, weight, mstAlgorithms[0].name, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package ads
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// arrayToInts returns the content of an Array holding int values.
+func arrayToInts(a *Array) ([]int, error) {
+	values := make([]int, a.Size())
+	for i := range values {
+		v, err := a.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		x, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("element %d is %T, want int", i, v)
+		}
+		values[i] = x
+	}
+	return values, nil
+}
+
+// SparseTable answers range queries over a static array in constant time. The monoid operation
+// must be idempotent (e.g. min, max or gcd) since queries combine two overlapping ranges.
+// Important: this implementation is one-indexed.
+type SparseTable struct {
+	m Monoid
+	// table[k][i] is the aggregate of the 2^k elements starting at (zero-based) i.
+	table [][]int
+	n     int
+}
+
+// NewSparseTable returns a sparse table over the given values, values[0] being stored at index 1.
+// The table is built in O(n log n).
+func NewSparseTable(m Monoid, values []int) *SparseTable {
+	n := len(values)
+	t := &SparseTable{m: m, n: n}
+	t.table = append(t.table, append([]int(nil), values...))
+	for k := 1; 1<<k <= n; k++ {
+		prev, half := t.table[k-1], 1<<(k-1)
+		row := make([]int, n-(1<<k)+1)
+		for i := range row {
+			row[i] = m.Combine(prev[i], prev[i+half])
+		}
+		t.table = append(t.table, row)
+	}
+	return t
+}
+
+// NewSparseTableFromArray returns a sparse table over the content of an Array. Every element of
+// the array must be an int.
+func NewSparseTableFromArray(m Monoid, a *Array) (*SparseTable, error) {
+	values, err := arrayToInts(a)
+	if err != nil {
+		return nil, err
+	}
+	return NewSparseTable(m, values), nil
+}
+
+// Query returns the aggregate of the elements in the closed range [l, r].
+func (t *SparseTable) Query(l, r int) (int, error) {
+	if l <= 0 || r > t.n || l > r {
+		return 0, fmt.Errorf("table size is %d, got invalid range [%d, %d]", t.n, l, r)
+	}
+	k := bits.Len(uint(r-l+1)) - 1
+	return t.m.Combine(t.table[k][l-1], t.table[k][r-(1<<k)]), nil
+}
+
+// Size returns the number of elements in the table.
+func (t *SparseTable) Size() int {
+	return t.n
+}
+
+// DisjointSparseTable answers range queries over a static array in constant time for any
+// associative operation, idempotent or not (e.g. sum or product).
+// Important: this implementation is one-indexed.
+type DisjointSparseTable struct {
+	m      Monoid
+	values []int
+	// table[k] holds, for every block of 2^(k+1) elements, the suffix aggregates of its left half
+	// and the prefix aggregates of its right half.
+	table [][]int
+	n     int
+}
+
+// NewDisjointSparseTable returns a disjoint sparse table over the given values, values[0] being
+// stored at index 1. The table is built in O(n log n).
+func NewDisjointSparseTable(m Monoid, values []int) *DisjointSparseTable {
+	n := len(values)
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	// Pad the values with the identity so every block is complete.
+	padded := make([]int, size)
+	copy(padded, values)
+	for i := n; i < size; i++ {
+		padded[i] = m.Identity
+	}
+	t := &DisjointSparseTable{m: m, values: padded, n: n}
+	for half := 1; half < size; half <<= 1 {
+		row := make([]int, size)
+		for mid := half; mid < size; mid += 2 * half {
+			row[mid-1] = padded[mid-1]
+			for i := mid - 2; i >= mid-half; i-- {
+				row[i] = m.Combine(padded[i], row[i+1])
+			}
+			row[mid] = padded[mid]
+			for i := mid + 1; i < mid+half; i++ {
+				row[i] = m.Combine(row[i-1], padded[i])
+			}
+		}
+		t.table = append(t.table, row)
+	}
+	return t
+}
+
+// NewDisjointSparseTableFromArray returns a disjoint sparse table over the content of an Array.
+// Every element of the array must be an int.
+func NewDisjointSparseTableFromArray(m Monoid, a *Array) (*DisjointSparseTable, error) {
+	values, err := arrayToInts(a)
+	if err != nil {
+		return nil, err
+	}
+	return NewDisjointSparseTable(m, values), nil
+}
+
+// Query returns the aggregate of the elements in the closed range [l, r].
+func (t *DisjointSparseTable) Query(l, r int) (int, error) {
+	if l <= 0 || r > t.n || l > r {
+		return 0, fmt.Errorf("table size is %d, got invalid range [%d, %d]", t.n, l, r)
+	}
+	l, r = l-1, r-1
+	if l == r {
+		return t.values[l], nil
+	}
+	// The highest differing bit selects the level where l and r fall in different halves.
+	k := bits.Len(uint(l^r)) - 1
+	return t.m.Combine(t.table[k][l], t.table[k][r]), nil
+}
+
+// Size returns the number of elements in the table.
+func (t *DisjointSparseTable) Size() int {
+	return t.n
+}
@@ -0,0 +1,108 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// rangeQuerier is implemented by the static range query structures.
+type rangeQuerier interface {
+	Query(l, r int) (int, error)
+	Size() int
+}
+
+func checkAllRanges(t *testing.T, q rangeQuerier, m Monoid, values []int) {
+	t.Helper()
+	if q.Size() != len(values) {
+		t.Fatalf("Size(): %d, want %d", q.Size(), len(values))
+	}
+	for l := 1; l <= len(values); l++ {
+		want := m.Identity
+		for r := l; r <= len(values); r++ {
+			want = m.Combine(want, values[r-1])
+			got, err := q.Query(l, r)
+			if err != nil {
+				t.Fatalf("Query(%d, %d) returned unexpected error; %v", l, r, err)
+			}
+			if got != want {
+				t.Fatalf("Query(%d, %d): %d, want %d", l, r, got, want)
+			}
+		}
+	}
+}
+
+func TestSparseTable(t *testing.T) {
+	monoids := map[string]Monoid{"min": MinMonoid, "max": MaxMonoid, "gcd": GCDMonoid}
+	for name, m := range monoids {
+		for _, n := range []int{0, 1, 2, 3, 8, 31, 100} {
+			t.Run(fmt.Sprintf("%s/size %d", name, n), func(t *testing.T) {
+				r := rand.New(rand.NewSource(int64(n)))
+				values := make([]int, n)
+				for i := range values {
+					values[i] = r.Intn(120) + 1
+				}
+				checkAllRanges(t, NewSparseTable(m, values), m, values)
+			})
+		}
+	}
+}
+
+func TestDisjointSparseTable(t *testing.T) {
+	product := Monoid{Combine: func(a, b int) int { return a * b % 1000003 }, Identity: 1}
+	monoids := map[string]Monoid{"sum": SumMonoid, "product": product, "min": MinMonoid}
+	for name, m := range monoids {
+		for _, n := range []int{0, 1, 2, 3, 8, 31, 100} {
+			t.Run(fmt.Sprintf("%s/size %d", name, n), func(t *testing.T) {
+				r := rand.New(rand.NewSource(int64(n)))
+				values := make([]int, n)
+				for i := range values {
+					values[i] = r.Intn(201) - 100
+				}
+				checkAllRanges(t, NewDisjointSparseTable(m, values), m, values)
+			})
+		}
+	}
+}
+
+func TestSparseTable_FromArray(t *testing.T) {
+	a := NewArray()
+	values := []int{5, 3, 8, 1, 9, 2}
+	for _, v := range values {
+		a.Add(v)
+	}
+	st, err := NewSparseTableFromArray(MinMonoid, a)
+	if err != nil {
+		t.Fatalf("NewSparseTableFromArray() returned unexpected error; %v", err)
+	}
+	checkAllRanges(t, st, MinMonoid, values)
+	dst, err := NewDisjointSparseTableFromArray(SumMonoid, a)
+	if err != nil {
+		t.Fatalf("NewDisjointSparseTableFromArray() returned unexpected error; %v", err)
+	}
+	checkAllRanges(t, dst, SumMonoid, values)
+
+	a.Add("not an int")
+	if _, err := NewSparseTableFromArray(MinMonoid, a); err == nil {
+		t.Error("NewSparseTableFromArray() returned nil error, want error")
+	}
+	if _, err := NewDisjointSparseTableFromArray(SumMonoid, a); err == nil {
+		t.Error("NewDisjointSparseTableFromArray() returned nil error, want error")
+	}
+}
+
+func TestSparseTable_Errors(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tables := map[string]rangeQuerier{
+		"sparse":          NewSparseTable(MinMonoid, values),
+		"disjoint sparse": NewDisjointSparseTable(SumMonoid, values),
+		"empty":           NewSparseTable(MinMonoid, nil),
+	}
+	for name, q := range tables {
+		for _, rng := range [][2]int{{0, 5}, {1, 11}, {6, 5}} {
+			if _, err := q.Query(rng[0], rng[1]); err == nil {
+				t.Errorf("%s: Query(%d, %d) returned nil error, want error", name, rng[0], rng[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package ads
+
+import "fmt"
+
+// rabinKarpModulus is the prime modulus used by RabinKarpSearch, 2³¹-1.
+const rabinKarpModulus = 1<<31 - 1
+
+// RollingHash keeps the PolynomialHash of a sliding window over a stream of bytes, updating it in
+// constant time as bytes enter and leave the window. It hashes bytes rather than runes, since a
+// window of k bytes may split a rune and searches report byte offsets, so both hashes agree on
+// ASCII text.
+type RollingHash struct {
+	modulus int
+	// high is p^(k-1) mod modulus, the weight of the oldest byte of a full window.
+	high int
+	// window is a ring buffer holding the last bytes rolled in; the oldest one is at start.
+	window        []byte
+	start, length int
+	hash          int
+}
+
+// NewRollingHash returns an empty rolling hash over windows of k bytes. The modulus must be
+// positive and at most a 256th of the largest int, so no intermediate value overflows.
+func NewRollingHash(k, modulus int) (*RollingHash, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("window size must be positive, got %d", k)
+	}
+	if modulus < 1 || modulus > maxInt/256 {
+		return nil, fmt.Errorf("modulus must be in [1, %d], got %d", maxInt/256, modulus)
+	}
+	r := &RollingHash{modulus: modulus, high: 1 % modulus, window: make([]byte, k)}
+	for i := 1; i < k; i++ {
+		r.high = r.high * hashTableFMultiplier % modulus
+	}
+	return r, nil
+}
+
+// Roll appends c to the window, dropping the oldest byte if the window was full, and returns the
+// hash of the new window.
+func (r *RollingHash) Roll(c byte) int {
+	if r.length == len(r.window) {
+		// The oldest byte is overwritten by c.
+		out := r.window[r.start]
+		r.hash = (r.hash - int(out)*r.high%r.modulus + r.modulus) % r.modulus
+		r.window[r.start] = c
+		r.start = (r.start + 1) % len(r.window)
+	} else {
+		r.window[(r.start+r.length)%len(r.window)] = c
+		r.length++
+	}
+	r.hash = polynomialHashAppend(r.hash, int(c), r.modulus)
+	return r.hash
+}
+
+// Hash returns the PolynomialHash of the bytes in the window.
+func (r *RollingHash) Hash() int {
+	return r.hash
+}
+
+// Len returns the number of bytes in the window.
+func (r *RollingHash) Len() int {
+	return r.length
+}
+
+// PrefixFunction returns the prefix function of s used by KMP: the i-th value is the length of the
+// longest proper prefix of s[:i+1] that is also a suffix of it. It takes O(n) time.
+func PrefixFunction(s string) []int {
+	pi := make([]int, len(s))
+	for i := 1; i < len(s); i++ {
+		k := pi[i-1]
+		for k > 0 && s[i] != s[k] {
+			k = pi[k-1]
+		}
+		if s[i] == s[k] {
+			k++
+		}
+		pi[i] = k
+	}
+	return pi
+}
+
+// ZFunction returns the Z-function of s: the i-th value is the length of the longest common
+// prefix of s and s[i:]. The first value is len(s). It takes O(n) time.
+func ZFunction(s string) []int {
+	z := make([]int, len(s))
+	if len(s) == 0 {
+		return z
+	}
+	z[0] = len(s)
+	// [l, r) is the rightmost segment found so far that matches a prefix of s.
+	for i, l, r := 1, 0, 0; i < len(s); i++ {
+		if i < r {
+			z[i] = minInts(r-i, z[i-l])
+		}
+		for i+z[i] < len(s) && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
+
+// emptyPatternMatches returns every position of text, where an empty pattern matches.
+func emptyPatternMatches(text string) []int {
+	matches := make([]int, len(text)+1)
+	for i := range matches {
+		matches[i] = i
+	}
+	return matches
+}
+
+// KMPSearch returns the byte offsets of every occurrence of pattern in text, overlapping ones
+// included, using the Knuth-Morris-Pratt algorithm. It takes O(n + m) time.
+func KMPSearch(text, pattern string) []int {
+	if len(pattern) == 0 {
+		return emptyPatternMatches(text)
+	}
+	pi := PrefixFunction(pattern)
+	var matches []int
+	// k is the length of the longest prefix of pattern ending at the current position.
+	k := 0
+	for i := 0; i < len(text); i++ {
+		for k > 0 && text[i] != pattern[k] {
+			k = pi[k-1]
+		}
+		if text[i] == pattern[k] {
+			k++
+		}
+		if k == len(pattern) {
+			matches = append(matches, i-k+1)
+			k = pi[k-1]
+		}
+	}
+	return matches
+}
+
+// ZSearch returns the byte offsets of every occurrence of pattern in text, overlapping ones
+// included, using the Z-function of pattern followed by text. It takes O(n + m) time.
+func ZSearch(text, pattern string) []int {
+	if len(pattern) == 0 {
+		return emptyPatternMatches(text)
+	}
+	z := ZFunction(pattern + text)
+	var matches []int
+	for i := len(pattern); i < len(z); i++ {
+		if z[i] >= len(pattern) {
+			matches = append(matches, i-len(pattern))
+		}
+	}
+	return matches
+}
+
+// RabinKarpSearch returns the byte offsets of every occurrence of pattern in text, overlapping
+// ones included. It compares the hash of pattern with a RollingHash over every window of text,
+// and checks the windows whose hash matches, so it takes O(n + m) expected time.
+func RabinKarpSearch(text, pattern string) []int {
+	if len(pattern) == 0 {
+		return emptyPatternMatches(text)
+	}
+	r, _ := NewRollingHash(len(pattern), rabinKarpModulus)
+	for i := 0; i < len(pattern); i++ {
+		r.Roll(pattern[i])
+	}
+	want := r.Hash()
+	r, _ = NewRollingHash(len(pattern), rabinKarpModulus)
+	var matches []int
+	for i := 0; i < len(text); i++ {
+		h := r.Roll(text[i])
+		if start := i - len(pattern) + 1; start >= 0 && h == want && text[start:i+1] == pattern {
+			matches = append(matches, start)
+		}
+	}
+	return matches
+}
+
+// PatternMatch is an occurrence of a pattern in a text.
+type PatternMatch struct {
+	// Pattern is the index of the pattern in the dictionary.
+	Pattern int
+	// Position is the byte offset of the occurrence in the text.
+	Position int
+}
+
+// acNode is a state of the Aho-Corasick automaton: the prefix of some patterns spelled by the path
+// from the root.
+type acNode struct {
+	children map[byte]int
+	// fail is the node of the longest proper suffix of this node that is in the trie.
+	fail int
+	// output is the nearest node reachable through fail links that ends a pattern, -1 if none.
+	output int
+	// patterns holds the indices of the patterns ending at this node.
+	patterns []int
+	depth    int
+}
+
+// AhoCorasick is an automaton that finds the occurrences of several patterns in a text at once. It
+// is a trie of the patterns with failure links to the longest suffix of each node in the trie.
+type AhoCorasick struct {
+	nodes []acNode
+}
+
+// NewAhoCorasick builds the automaton for a dictionary of non-empty patterns. It takes time linear
+// in the total length of the patterns.
+func NewAhoCorasick(patterns ...string) (*AhoCorasick, error) {
+	a := &AhoCorasick{nodes: []acNode{{children: map[byte]int{}, output: -1}}}
+	for i, p := range patterns {
+		if len(p) == 0 {
+			return nil, fmt.Errorf("pattern %d is empty", i)
+		}
+		v := 0
+		for j := 0; j < len(p); j++ {
+			next, ok := a.nodes[v].children[p[j]]
+			if !ok {
+				next = len(a.nodes)
+				a.nodes = append(a.nodes, acNode{children: map[byte]int{}, output: -1, depth: j + 1})
+				a.nodes[v].children[p[j]] = next
+			}
+			v = next
+		}
+		a.nodes[v].patterns = append(a.nodes[v].patterns, i)
+	}
+	// Fail links point to shallower nodes, so they're computed in BFS order. Every node is
+	// enqueued once.
+	q := NewArrayBasedQueue(uint(len(a.nodes)))
+	for _, child := range a.nodes[0].children {
+		q.Push(child)
+	}
+	for q.Size() > 0 {
+		x, _ := q.Pop()
+		v := x.(int)
+		for c, child := range a.nodes[v].children {
+			f := a.nodes[v].fail
+			if v != 0 {
+				f = a.step(f, c)
+			}
+			a.nodes[child].fail = f
+			if len(a.nodes[f].patterns) > 0 {
+				a.nodes[child].output = f
+			} else {
+				a.nodes[child].output = a.nodes[f].output
+			}
+			q.Push(child)
+		}
+	}
+	return a, nil
+}
+
+// step returns the node reached from v reading c, following fail links until some node has a
+// child for c.
+func (a *AhoCorasick) step(v int, c byte) int {
+	for {
+		if next, ok := a.nodes[v].children[c]; ok {
+			return next
+		}
+		if v == 0 {
+			return 0
+		}
+		v = a.nodes[v].fail
+	}
+}
+
+// FindAll returns every occurrence of the patterns in text, overlapping ones included, ordered by
+// the position where they end and then from the longest pattern to the shortest. Equal patterns
+// are reported in the order they were given. It takes O(n + k) amortized time for k occurrences.
+func (a *AhoCorasick) FindAll(text string) []PatternMatch {
+	var matches []PatternMatch
+	v := 0
+	for i := 0; i < len(text); i++ {
+		v = a.step(v, text[i])
+		for u := v; u != -1; u = a.nodes[u].output {
+			for _, p := range a.nodes[u].patterns {
+				matches = append(matches, PatternMatch{Pattern: p, Position: i + 1 - a.nodes[u].depth})
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,197 @@
+package ads
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// stringSearches lists every single pattern search implementation.
+var stringSearches = []struct {
+	name   string
+	search func(text, pattern string) []int
+}{
+	{name: "kmp", search: KMPSearch},
+	{name: "z", search: ZSearch},
+	{name: "rabin-karp", search: RabinKarpSearch},
+}
+
+// naiveSearch returns the byte offsets of every occurrence of pattern in text.
+func naiveSearch(text, pattern string) []int {
+	var matches []int
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if text[i:i+len(pattern)] == pattern {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// randomBinaryString returns a string of up to maxLen a's and b's, so patterns repeat often.
+func randomBinaryString(r *rand.Rand, maxLen int) string {
+	b := make([]byte, r.Intn(maxLen+1))
+	for i := range b {
+		b[i] = "ab"[r.Intn(2)]
+	}
+	return string(b)
+}
+
+func TestPrefixAndZFunctions(t *testing.T) {
+	tests := []struct {
+		s         string
+		prefix, z []int
+	}{
+		{s: "", prefix: []int{}, z: []int{}},
+		{s: "a", prefix: []int{0}, z: []int{1}},
+		{s: "aabaaab", prefix: []int{0, 1, 0, 1, 2, 2, 3}, z: []int{7, 1, 0, 2, 3, 1, 0}},
+		{s: "abacaba", prefix: []int{0, 0, 1, 0, 1, 2, 3}, z: []int{7, 0, 1, 0, 3, 0, 1}},
+		{s: "aaaaa", prefix: []int{0, 1, 2, 3, 4}, z: []int{5, 4, 3, 2, 1}},
+	}
+	for _, test := range tests {
+		if diff := cmp.Diff(test.prefix, PrefixFunction(test.s)); diff != "" {
+			t.Errorf("PrefixFunction(%q) mismatch (-want +got):\n%s", test.s, diff)
+		}
+		if diff := cmp.Diff(test.z, ZFunction(test.s)); diff != "" {
+			t.Errorf("ZFunction(%q) mismatch (-want +got):\n%s", test.s, diff)
+		}
+	}
+}
+
+func TestStringSearch(t *testing.T) {
+	tests := []struct {
+		text, pattern string
+		want          []int
+	}{
+		{text: "abababa", pattern: "aba", want: []int{0, 2, 4}},
+		{text: "aaaa", pattern: "aa", want: []int{0, 1, 2}},
+		{text: "abc", pattern: "abcd"},
+		{text: "abc", pattern: "d"},
+		{text: "abc", pattern: "", want: []int{0, 1, 2, 3}},
+		{text: "", pattern: "", want: []int{0}},
+		{text: "日本日本", pattern: "本", want: []int{3, 9}},
+	}
+	for _, s := range stringSearches {
+		for _, test := range tests {
+			if diff := cmp.Diff(test.want, s.search(test.text, test.pattern)); diff != "" {
+				t.Errorf("%s(%q, %q) mismatch (-want +got):\n%s", s.name, test.text, test.pattern, diff)
+			}
+		}
+	}
+}
+
+func TestStringSearchRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		text, pattern := randomBinaryString(r, 40), randomBinaryString(r, 5)
+		if i%2 == 0 {
+			text, pattern = randomRuneString(r, 20), randomRuneString(r, 3)
+		}
+		if pattern == "" {
+			continue
+		}
+		want := naiveSearch(text, pattern)
+		for _, s := range stringSearches {
+			if diff := cmp.Diff(want, s.search(text, pattern)); diff != "" {
+				t.Fatalf("%s(%q, %q) mismatch (-want +got):\n%s", s.name, text, pattern, diff)
+			}
+		}
+	}
+}
+
+func TestRollingHash(t *testing.T) {
+	if _, err := NewRollingHash(0, 7); err == nil {
+		t.Error("NewRollingHash(0, 7) returned nil error, want error")
+	}
+	if _, err := NewRollingHash(3, 0); err == nil {
+		t.Error("NewRollingHash(3, 0) returned nil error, want error")
+	}
+	r := rand.New(rand.NewSource(1))
+	// The text is ASCII, so window hashes match PolynomialHash.
+	b := make([]byte, 200)
+	for i := range b {
+		b[i] = byte(r.Intn(128))
+	}
+	text := string(b)
+	for _, k := range []int{1, 2, 5, 17} {
+		for _, m := range []int{1, 8, 1000003, rabinKarpModulus} {
+			h, err := NewRollingHash(k, m)
+			if err != nil {
+				t.Fatalf("NewRollingHash(%d, %d) returned unexpected error; %v", k, m, err)
+			}
+			for i := 0; i < len(text); i++ {
+				start := i - k + 1
+				if start < 0 {
+					start = 0
+				}
+				want := PolynomialHash(text[start:i+1], m)
+				if got := h.Roll(text[i]); got != want || h.Hash() != want || h.Len() != i+1-start {
+					t.Fatalf("k=%d, m=%d: Roll(%q) returned %d with %d bytes, want %d with %d bytes",
+						k, m, text[i], got, h.Len(), want, i+1-start)
+				}
+			}
+		}
+	}
+}
+
+func TestAhoCorasick(t *testing.T) {
+	a, err := NewAhoCorasick("he", "she", "his", "hers", "he")
+	if err != nil {
+		t.Fatalf("NewAhoCorasick() returned unexpected error; %v", err)
+	}
+	want := []PatternMatch{
+		{Pattern: 1, Position: 1}, {Pattern: 0, Position: 2}, {Pattern: 4, Position: 2},
+		{Pattern: 3, Position: 2},
+	}
+	if diff := cmp.Diff(want, a.FindAll("ushers")); diff != "" {
+		t.Errorf("FindAll(\"ushers\") mismatch (-want +got):\n%s", diff)
+	}
+	if got := a.FindAll("xyz"); len(got) != 0 {
+		t.Errorf("FindAll(\"xyz\") returned %v, want no matches", got)
+	}
+	if _, err := NewAhoCorasick("a", ""); err == nil {
+		t.Error("NewAhoCorasick() returned nil error for an empty pattern, want error")
+	}
+}
+
+func TestAhoCorasickRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		var patterns []string
+		for j := r.Intn(8); j >= 0; j-- {
+			if p := randomBinaryString(r, 6); p != "" {
+				patterns = append(patterns, p)
+			}
+		}
+		a, err := NewAhoCorasick(patterns...)
+		if err != nil {
+			t.Fatalf("NewAhoCorasick(%q) returned unexpected error; %v", patterns, err)
+		}
+		text := randomBinaryString(r, 60)
+		// Count the matches of each pattern ending at each position and compare them with the
+		// occurrences found by a naive search.
+		found := map[PatternMatch]int{}
+		last, lastLen := -1, 0
+		for _, m := range a.FindAll(text) {
+			end, length := m.Position+len(patterns[m.Pattern]), len(patterns[m.Pattern])
+			if end < last || (end == last && length > lastLen) {
+				t.Fatalf("FindAll() reported %v out of order", m)
+			}
+			last, lastLen = end, length
+			if !strings.HasPrefix(text[m.Position:], patterns[m.Pattern]) {
+				t.Fatalf("FindAll() reported %v, but pattern %q isn't there", m, patterns[m.Pattern])
+			}
+			found[m]++
+		}
+		want := map[PatternMatch]int{}
+		for p, pattern := range patterns {
+			for _, pos := range naiveSearch(text, pattern) {
+				want[PatternMatch{Pattern: p, Position: pos}]++
+			}
+		}
+		if diff := cmp.Diff(want, found); diff != "" {
+			t.Fatalf("FindAll() mismatch for %q in %q (-want +got):\n%s", patterns, text, diff)
+		}
+	}
+}
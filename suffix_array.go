@@ -0,0 +1,260 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SuffixArray is the sorted list of the suffixes of a text, along with the longest common prefix
+// of each pair of adjacent suffixes. It answers substring queries over the text without scanning
+// it. Suffixes are identified by their byte offset.
+type SuffixArray struct {
+	text string
+	sa   []int
+	// rank is the inverse of sa: the position of each suffix in sorted order.
+	rank []int
+	// lcp[i] is the length of the longest common prefix of suffixes sa[i-1] and sa[i], lcp[0] = 0.
+	lcp []int
+	// lcpMin answers range minimum queries over lcp.
+	lcpMin *SparseTable
+}
+
+// NewSuffixArray returns the suffix array of text, built by prefix doubling in O(n log n) time,
+// and its LCP array, built with Kasai's algorithm in O(n) time.
+func NewSuffixArray(text string) *SuffixArray {
+	s := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		s[i] = int(text[i])
+	}
+	a := &SuffixArray{text: text, sa: buildSuffixArray(s, 256)}
+	a.rank, a.lcp = kasai(s, a.sa)
+	a.lcpMin = NewSparseTable(MinMonoid, a.lcp)
+	return a
+}
+
+// buildSuffixArray sorts the suffixes of s, whose symbols are in [0, alphabet). After round k, the
+// suffixes are sorted by their first 2^k symbols, and rank holds their equivalence classes; each
+// round sorts the pairs of classes of the two halves with two counting sorts.
+func buildSuffixArray(s []int, alphabet int) []int {
+	n := len(s)
+	if n == 0 {
+		return nil
+	}
+	sa, rank, tmp := make([]int, n), make([]int, n), make([]int, n)
+	count := make([]int, maxInts(alphabet, n)+1)
+	for _, c := range s {
+		count[c+1]++
+	}
+	for c := 1; c < len(count); c++ {
+		count[c] += count[c-1]
+	}
+	for i, c := range s {
+		sa[count[c]] = i
+		count[c]++
+	}
+	for j := 1; j < n; j++ {
+		rank[sa[j]] = rank[sa[j-1]]
+		if s[sa[j]] != s[sa[j-1]] {
+			rank[sa[j]]++
+		}
+	}
+	for k := 1; k < n && rank[sa[n-1]] < n-1; k <<= 1 {
+		// Order by the class of the second half: suffixes shorter than k come first.
+		p := 0
+		for i := n - k; i < n; i++ {
+			tmp[p], p = i, p+1
+		}
+		for _, i := range sa {
+			if i >= k {
+				tmp[p], p = i-k, p+1
+			}
+		}
+		// Stable sort by the class of the first half.
+		classes := rank[sa[n-1]] + 1
+		for c := 0; c <= classes; c++ {
+			count[c] = 0
+		}
+		for _, i := range tmp {
+			count[rank[i]+1]++
+		}
+		for c := 1; c <= classes; c++ {
+			count[c] += count[c-1]
+		}
+		for _, i := range tmp {
+			sa[count[rank[i]]] = i
+			count[rank[i]]++
+		}
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		tmp[sa[0]] = 0
+		for j := 1; j < n; j++ {
+			a, b := sa[j-1], sa[j]
+			tmp[b] = tmp[a]
+			if rank[a] != rank[b] || second(a) != second(b) {
+				tmp[b]++
+			}
+		}
+		rank, tmp = tmp, rank
+	}
+	return sa
+}
+
+// kasai returns the inverse of the suffix array sa of s and its LCP array. Moving from suffix i to
+// i+1 shortens the common prefix with the preceding suffix by at most one, so it takes O(n) time.
+func kasai(s, sa []int) ([]int, []int) {
+	n := len(s)
+	rank, lcp := make([]int, n), make([]int, n)
+	for i, v := range sa {
+		rank[v] = i
+	}
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return rank, lcp
+}
+
+// Text returns the indexed text.
+func (a *SuffixArray) Text() string {
+	return a.text
+}
+
+// Suffixes returns the byte offsets of the suffixes of the text in lexicographic order.
+func (a *SuffixArray) Suffixes() []int {
+	return append([]int(nil), a.sa...)
+}
+
+// LCP returns the LCP array: the i-th value is the length of the longest common prefix of the
+// (i-1)-th and i-th suffixes in lexicographic order. The first value is 0.
+func (a *SuffixArray) LCP() []int {
+	return append([]int(nil), a.lcp...)
+}
+
+// LongestCommonPrefix returns the length of the longest common prefix of the suffixes starting at
+// byte offsets i and j. It's the minimum of the LCP array between both suffixes, answered in
+// constant time by a SparseTable.
+func (a *SuffixArray) LongestCommonPrefix(i, j int) (int, error) {
+	n := len(a.text)
+	if i < 0 || i >= n || j < 0 || j >= n {
+		return 0, fmt.Errorf("text length is %d, got invalid suffixes %d and %d", n, i, j)
+	}
+	if i == j {
+		return n - i, nil
+	}
+	l, r := a.rank[i], a.rank[j]
+	if l > r {
+		l, r = r, l
+	}
+	// The table is one-indexed, lcp[l+1] is at l+2.
+	return a.lcpMin.Query(l+2, r+1)
+}
+
+// bounds returns the range [lo, hi) of sorted suffixes starting with pattern.
+func (a *SuffixArray) bounds(pattern string) (int, int) {
+	// prefix returns the first len(pattern) bytes of the i-th sorted suffix.
+	prefix := func(i int) string {
+		s := a.text[a.sa[i]:]
+		if len(s) > len(pattern) {
+			s = s[:len(pattern)]
+		}
+		return s
+	}
+	lo := sort.Search(len(a.sa), func(i int) bool { return prefix(i) >= pattern })
+	hi := sort.Search(len(a.sa), func(i int) bool { return prefix(i) > pattern })
+	return lo, hi
+}
+
+// Count returns the number of occurrences of pattern in the text, overlapping ones included. It
+// takes O(m log n) time.
+func (a *SuffixArray) Count(pattern string) int {
+	if len(pattern) == 0 {
+		return len(a.text) + 1
+	}
+	lo, hi := a.bounds(pattern)
+	return hi - lo
+}
+
+// Occurrences returns the byte offsets of every occurrence of pattern in the text in increasing
+// order, overlapping ones included.
+func (a *SuffixArray) Occurrences(pattern string) []int {
+	if len(pattern) == 0 {
+		return emptyPatternMatches(a.text)
+	}
+	lo, hi := a.bounds(pattern)
+	if lo == hi {
+		return nil
+	}
+	matches := append([]int(nil), a.sa[lo:hi]...)
+	sort.Ints(matches)
+	return matches
+}
+
+// LongestRepeatedSubstring returns the longest substring occurring at least twice in the text,
+// possibly overlapping. Among several ones, the lexicographically smallest is returned.
+func (a *SuffixArray) LongestRepeatedSubstring() string {
+	if len(a.lcp) == 0 {
+		return ""
+	}
+	best := 0
+	for i, h := range a.lcp {
+		if h > a.lcp[best] {
+			best = i
+		}
+	}
+	return a.text[a.sa[best] : a.sa[best]+a.lcp[best]]
+}
+
+// DistinctSubstrings returns the number of distinct non-empty substrings of the text. Each suffix
+// adds its prefixes but the ones shared with the preceding suffix.
+func (a *SuffixArray) DistinctSubstrings() int {
+	n := len(a.text)
+	count := n * (n + 1) / 2
+	for _, h := range a.lcp {
+		count -= h
+	}
+	return count
+}
+
+// LongestCommonSubstring returns the longest string occurring in both s and t. Among several ones,
+// the lexicographically smallest is returned. It builds the suffix array of s and t joined by a
+// separator out of the byte range, and looks for the longest common prefix of adjacent suffixes
+// coming from different strings, so it takes O((n + m) log(n + m)) time.
+func LongestCommonSubstring(s, t string) string {
+	joined := make([]int, 0, len(s)+len(t)+1)
+	for i := 0; i < len(s); i++ {
+		joined = append(joined, int(s[i]))
+	}
+	// The separator appears once, so no common prefix goes past it.
+	joined = append(joined, 256)
+	for i := 0; i < len(t); i++ {
+		joined = append(joined, int(t[i]))
+	}
+	sa := buildSuffixArray(joined, 257)
+	_, lcp := kasai(joined, sa)
+	start, length := 0, 0
+	for i := 1; i < len(sa); i++ {
+		if (sa[i-1] < len(s)) != (sa[i] < len(s)) && lcp[i] > length {
+			start, length = sa[i], lcp[i]
+		}
+	}
+	if start > len(s) {
+		start -= len(s) + 1
+		return t[start : start+length]
+	}
+	return s[start : start+length]
+}
@@ -0,0 +1,141 @@
+package ads
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// naiveSuffixArray sorts the suffixes of text by comparing them as strings.
+func naiveSuffixArray(text string) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool { return text[sa[i]:] < text[sa[j]:] })
+	return sa
+}
+
+// commonPrefixLength returns the length of the longest common prefix of s and t.
+func commonPrefixLength(s, t string) int {
+	n := 0
+	for n < len(s) && n < len(t) && s[n] == t[n] {
+		n++
+	}
+	return n
+}
+
+// naiveLongestCommonSubstring tries every substring of s, longest first.
+func naiveLongestCommonSubstring(s, t string) string {
+	for length := len(s); length > 0; length-- {
+		var found []string
+		for i := 0; i+length <= len(s); i++ {
+			if strings.Contains(t, s[i:i+length]) {
+				found = append(found, s[i:i+length])
+			}
+		}
+		if len(found) > 0 {
+			sort.Strings(found)
+			return found[0]
+		}
+	}
+	return ""
+}
+
+func TestSuffixArray(t *testing.T) {
+	a := NewSuffixArray("banana")
+	if diff := cmp.Diff([]int{5, 3, 1, 0, 4, 2}, a.Suffixes()); diff != "" {
+		t.Errorf("Suffixes() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{0, 1, 3, 0, 0, 2}, a.LCP()); diff != "" {
+		t.Errorf("LCP() mismatch (-want +got):\n%s", diff)
+	}
+	if got := a.Count("ana"); got != 2 {
+		t.Errorf("Count(\"ana\"): %d, want 2", got)
+	}
+	if diff := cmp.Diff([]int{1, 3}, a.Occurrences("ana")); diff != "" {
+		t.Errorf("Occurrences(\"ana\") mismatch (-want +got):\n%s", diff)
+	}
+	if got := a.Occurrences("nab"); got != nil {
+		t.Errorf("Occurrences(\"nab\"): %v, want none", got)
+	}
+	if got := a.LongestRepeatedSubstring(); got != "ana" {
+		t.Errorf("LongestRepeatedSubstring(): %q, want \"ana\"", got)
+	}
+	if got := a.DistinctSubstrings(); got != 15 {
+		t.Errorf("DistinctSubstrings(): %d, want 15", got)
+	}
+	if got, err := a.LongestCommonPrefix(1, 3); err != nil || got != 3 {
+		t.Errorf("LongestCommonPrefix(1, 3): %d, %v, want 3", got, err)
+	}
+	if _, err := a.LongestCommonPrefix(0, 6); err == nil {
+		t.Error("LongestCommonPrefix(0, 6) returned nil error, want error")
+	}
+	empty := NewSuffixArray("")
+	if empty.Count("") != 1 || empty.Count("a") != 0 || empty.LongestRepeatedSubstring() != "" {
+		t.Error("empty suffix array reports occurrences")
+	}
+	if got := LongestCommonSubstring("xabcdey", "zzbcdabc"); got != "abc" {
+		t.Errorf("LongestCommonSubstring(): %q, want \"abc\"", got)
+	}
+}
+
+func TestSuffixArrayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		text := randomBinaryString(r, 50)
+		if i%2 == 0 {
+			text = randomRuneString(r, 15)
+		}
+		a := NewSuffixArray(text)
+		sa := a.Suffixes()
+		if diff := cmp.Diff(naiveSuffixArray(text), sa); len(text) > 0 && diff != "" {
+			t.Fatalf("Suffixes() of %q mismatch (-want +got):\n%s", text, diff)
+		}
+		lcp := a.LCP()
+		longest, distinct := "", map[string]bool{}
+		for j := range sa {
+			if j > 0 {
+				if want := commonPrefixLength(text[sa[j-1]:], text[sa[j]:]); lcp[j] != want {
+					t.Fatalf("LCP()[%d] of %q: %d, want %d", j, text, lcp[j], want)
+				}
+			}
+			for k := sa[j] + 1; k <= len(text); k++ {
+				distinct[text[sa[j]:k]] = true
+			}
+		}
+		for s := range distinct {
+			if len(naiveSearch(text, s)) < 2 {
+				continue
+			}
+			if len(s) > len(longest) || len(s) == len(longest) && s < longest {
+				longest = s
+			}
+		}
+		if got := a.LongestRepeatedSubstring(); got != longest {
+			t.Fatalf("LongestRepeatedSubstring() of %q: %q, want %q", text, got, longest)
+		}
+		if got := a.DistinctSubstrings(); got != len(distinct) {
+			t.Fatalf("DistinctSubstrings() of %q: %d, want %d", text, got, len(distinct))
+		}
+		for j := 0; j < 5 && len(text) > 0; j++ {
+			x, y := r.Intn(len(text)), r.Intn(len(text))
+			if got, _ := a.LongestCommonPrefix(x, y); got != commonPrefixLength(text[x:], text[y:]) {
+				t.Fatalf("LongestCommonPrefix(%d, %d) of %q: %d", x, y, text, got)
+			}
+		}
+		pattern := randomBinaryString(r, 4)
+		want := naiveSearch(text, pattern)
+		if !cmp.Equal(want, a.Occurrences(pattern)) || a.Count(pattern) != len(want) {
+			t.Fatalf("Occurrences(%q) of %q: %v, want %v", pattern, text, a.Occurrences(pattern), want)
+		}
+		other := randomBinaryString(r, 30)
+		got, wantLCS := LongestCommonSubstring(text, other), naiveLongestCommonSubstring(text, other)
+		if got != wantLCS {
+			t.Fatalf("LongestCommonSubstring(%q, %q): %q, want %q", text, other, got, wantLCS)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package ads
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// WeightedKey is a key stored in a TernarySearchTree and its weight.
+type WeightedKey struct {
+	Key    string
+	Weight int
+}
+
+// FuzzyMatch is a key found by a fuzzy lookup, its weight and its edit distance to the query.
+type FuzzyMatch struct {
+	Key      string
+	Weight   int
+	Distance int
+}
+
+// tstNode holds a rune, the weight of the key ending at it and three children: keys whose rune
+// at this position is smaller (left) or greater (right), and keys continuing with it (mid).
+type tstNode struct {
+	r                rune
+	weight           int
+	terminal         bool
+	left, mid, right *tstNode
+}
+
+// TernarySearchTree stores weighted string keys for autocompletion. Each node branches like a
+// binary search tree on a single rune, which takes less memory than a trie for sparse alphabets.
+// Keys are split into runes, with invalid UTF-8 bytes read as utf8.RuneError. The tree isn't
+// balanced, so inserting keys in random order gives the best performance.
+type TernarySearchTree struct {
+	root   *tstNode
+	length int
+}
+
+// NewTernarySearchTree returns an empty ternary search tree.
+func NewTernarySearchTree() *TernarySearchTree {
+	return &TernarySearchTree{}
+}
+
+// find returns the node where the key k ends, or nil.
+func (t *TernarySearchTree) find(k []rune) *tstNode {
+	n, i := t.root, 0
+	for n != nil {
+		switch {
+		case k[i] < n.r:
+			n = n.left
+		case k[i] > n.r:
+			n = n.right
+		case i == len(k)-1:
+			return n
+		default:
+			n, i = n.mid, i+1
+		}
+	}
+	return nil
+}
+
+// Insert sets or updates the weight of the given key. The empty key can't be stored.
+func (t *TernarySearchTree) Insert(k string, weight int) error {
+	rs := []rune(k)
+	if len(rs) == 0 {
+		return fmt.Errorf("cannot insert an empty key")
+	}
+	link, i := &t.root, 0
+	for {
+		if *link == nil {
+			*link = &tstNode{r: rs[i]}
+		}
+		n := *link
+		switch {
+		case rs[i] < n.r:
+			link = &n.left
+		case rs[i] > n.r:
+			link = &n.right
+		case i == len(rs)-1:
+			if !n.terminal {
+				n.terminal = true
+				t.length++
+			}
+			n.weight = weight
+			return nil
+		default:
+			link, i = &n.mid, i+1
+		}
+	}
+}
+
+// Get the weight of the given key. Returns 0, false if it doesn't exist.
+func (t *TernarySearchTree) Get(k string) (int, bool) {
+	rs := []rune(k)
+	if len(rs) == 0 {
+		return 0, false
+	}
+	if n := t.find(rs); n != nil && n.terminal {
+		return n.weight, true
+	}
+	return 0, false
+}
+
+// Delete the given key (if exists). Nodes left without keys are pruned.
+func (t *TernarySearchTree) Delete(k string) {
+	if rs := []rune(k); len(rs) > 0 {
+		t.root = t.delete(t.root, rs, 0)
+	}
+}
+
+func (t *TernarySearchTree) delete(n *tstNode, k []rune, i int) *tstNode {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case k[i] < n.r:
+		n.left = t.delete(n.left, k, i)
+	case k[i] > n.r:
+		n.right = t.delete(n.right, k, i)
+	case i == len(k)-1:
+		if n.terminal {
+			n.terminal, n.weight = false, 0
+			t.length--
+		}
+	default:
+		n.mid = t.delete(n.mid, k, i+1)
+	}
+	if n.terminal || n.mid != nil {
+		return n
+	}
+	// The node holds no key, join its siblings by hanging the left subtree below the smallest
+	// node of the right one.
+	if n.right == nil {
+		return n.left
+	}
+	min := n.right
+	for min.left != nil {
+		min = min.left
+	}
+	min.left = n.left
+	return n.right
+}
+
+// Size returns the number of keys stored in the tree.
+func (t *TernarySearchTree) Size() int {
+	return t.length
+}
+
+// Empty removes all keys from the tree.
+func (t *TernarySearchTree) Empty() {
+	t.root = nil
+	t.length = 0
+}
+
+// tstWalk calls f with every key of the subtree rooted at n in lexicographic order, where prefix
+// is the key spelled by the path to n (excluding n's rune).
+func tstWalk(n *tstNode, prefix []rune, f func(k []rune, n *tstNode)) {
+	if n == nil {
+		return
+	}
+	tstWalk(n.left, prefix, f)
+	key := append(prefix, n.r)
+	if n.terminal {
+		f(key, n)
+	}
+	tstWalk(n.mid, key, f)
+	tstWalk(n.right, prefix, f)
+}
+
+// rankedBelow returns whether a ranks below b in TopK: it's lighter or, being as heavy, it's
+// lexicographically larger.
+func rankedBelow(a, b WeightedKey) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	return a.Key > b.Key
+}
+
+// weightedKeyHeap implements heap.Interface for TopK, keeping the lowest ranked key on top.
+type weightedKeyHeap []WeightedKey
+
+func (h weightedKeyHeap) Len() int            { return len(h) }
+func (h weightedKeyHeap) Less(i, j int) bool  { return rankedBelow(h[i], h[j]) }
+func (h weightedKeyHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *weightedKeyHeap) Push(x interface{}) { *h = append(*h, x.(WeightedKey)) }
+func (h *weightedKeyHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// TopK returns the (at most) k keys starting with prefix with the highest weights, sorted by
+// decreasing weight and then lexicographically; keys tying at the k-th weight are cut in the same
+// order. Candidates are kept in a min-heap of size k, so it takes O(m log k) time for m matching
+// keys.
+func (t *TernarySearchTree) TopK(prefix string, k int) []WeightedKey {
+	if k <= 0 {
+		return []WeightedKey{}
+	}
+	h := make(weightedKeyHeap, 0, k)
+	offer := func(key []rune, n *tstNode) {
+		c := WeightedKey{Key: string(key), Weight: n.weight}
+		if len(h) < k {
+			heap.Push(&h, c)
+		} else if rankedBelow(h[0], c) {
+			h[0] = c
+			heap.Fix(&h, 0)
+		}
+	}
+	if rs := []rune(prefix); len(rs) == 0 {
+		tstWalk(t.root, nil, offer)
+	} else if n := t.find(rs); n != nil {
+		if n.terminal {
+			offer(rs, n)
+		}
+		tstWalk(n.mid, rs, offer)
+	}
+	top := []WeightedKey(h)
+	sort.Slice(top, func(i, j int) bool { return rankedBelow(top[j], top[i]) })
+	return top
+}
+
+// Fuzzy returns the keys within Levenshtein distance d of s, sorted by increasing distance, then
+// by decreasing weight and then lexicographically. The search computes one row of the edit
+// distance table per node and abandons every branch whose row exceeds d.
+func (t *TernarySearchTree) Fuzzy(s string, d int) []FuzzyMatch {
+	if d < 0 {
+		return []FuzzyMatch{}
+	}
+	query := []rune(s)
+	row := make([]int, len(query)+1)
+	for j := range row {
+		row[j] = j
+	}
+	matches := []FuzzyMatch{}
+	t.fuzzy(t.root, query, nil, row, d, &matches)
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Key < b.Key
+	})
+	return matches
+}
+
+// fuzzy collects the matches of the subtree rooted at n, where prev is the edit distance row of
+// the key spelled by the path to n (excluding n's rune).
+func (t *TernarySearchTree) fuzzy(n *tstNode, query, prefix []rune, prev []int, d int, matches *[]FuzzyMatch) {
+	if n == nil {
+		return
+	}
+	t.fuzzy(n.left, query, prefix, prev, d, matches)
+	t.fuzzy(n.right, query, prefix, prev, d, matches)
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	best := row[0]
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if query[j-1] == n.r {
+			cost = 0
+		}
+		row[j] = minInts(row[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		if row[j] < best {
+			best = row[j]
+		}
+	}
+	if best > d {
+		return
+	}
+	key := append(prefix[:len(prefix):len(prefix)], n.r)
+	if n.terminal && row[len(row)-1] <= d {
+		*matches = append(*matches, FuzzyMatch{Key: string(key), Weight: n.weight, Distance: row[len(row)-1]})
+	}
+	t.fuzzy(n.mid, query, key, row, d, matches)
+}
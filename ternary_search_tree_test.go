@@ -0,0 +1,167 @@
+package ads
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range ra {
+		row := make([]int, len(rb)+1)
+		row[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			row[j+1] = minInts(row[j]+1, prev[j+1]+1, prev[j]+cost)
+		}
+		prev = row
+	}
+	return prev[len(rb)]
+}
+
+func TestTernarySearchTree_ThroughOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewTernarySearchTree()
+	control := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		k := randomRuneString(r, 5)
+		if k == "" {
+			if err := tree.Insert(k, i); err == nil {
+				t.Fatal(`Insert("") returned nil error, want error`)
+			}
+			continue
+		}
+		if r.Intn(3) == 0 {
+			tree.Delete(k)
+			delete(control, k)
+		} else {
+			if err := tree.Insert(k, i); err != nil {
+				t.Fatalf("Insert(%q) returned unexpected error; %v", k, err)
+			}
+			control[k] = i
+		}
+		if tree.Size() != len(control) {
+			t.Fatalf("Size(): %d, want %d", tree.Size(), len(control))
+		}
+		q := randomRuneString(r, 5)
+		got, ok := tree.Get(q)
+		want, wantOk := control[q]
+		if ok != wantOk || got != want {
+			t.Fatalf("Get(%q): %v, %v, want %v, %v", q, got, ok, want, wantOk)
+		}
+	}
+	// Weights are distinct, so the top keys are fully determined.
+	for i := 0; i < 300; i++ {
+		prefix, k := randomRuneString(r, 2), r.Intn(10)
+		want := []WeightedKey{}
+		for key, w := range control {
+			if strings.HasPrefix(key, prefix) {
+				want = append(want, WeightedKey{Key: key, Weight: w})
+			}
+		}
+		sort.Slice(want, func(i, j int) bool { return want[i].Weight > want[j].Weight })
+		if len(want) > k {
+			want = want[:k]
+		}
+		if diff := cmp.Diff(want, tree.TopK(prefix, k)); diff != "" {
+			t.Fatalf("TopK(%q, %d) produced unwanted keys (-want +got):\n%s", prefix, k, diff)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		q, d := randomRuneString(r, 5), r.Intn(3)
+		want := []FuzzyMatch{}
+		for key, w := range control {
+			if dist := levenshtein(q, key); dist <= d {
+				want = append(want, FuzzyMatch{Key: key, Weight: w, Distance: dist})
+			}
+		}
+		sort.Slice(want, func(i, j int) bool {
+			if want[i].Distance != want[j].Distance {
+				return want[i].Distance < want[j].Distance
+			}
+			return want[i].Weight > want[j].Weight
+		})
+		if diff := cmp.Diff(want, tree.Fuzzy(q, d)); diff != "" {
+			t.Fatalf("Fuzzy(%q, %d) produced unwanted matches (-want +got):\n%s", q, d, diff)
+		}
+	}
+	tree.Empty()
+	if tree.Size() != 0 || len(tree.TopK("", 1)) != 0 {
+		t.Error("Empty() left keys in the tree")
+	}
+}
+
+func TestTernarySearchTree_TopKTies(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	words := []WeightedKey{{"a", 1}, {"b", 1}, {"c", 2}, {"d", 5}, {"e", 5}, {"f", 5}, {"g", 9}}
+	tests := []struct {
+		k    int
+		want []WeightedKey
+	}{
+		{k: 2, want: []WeightedKey{{"g", 9}, {"d", 5}}},
+		{k: 3, want: []WeightedKey{{"g", 9}, {"d", 5}, {"e", 5}}},
+		{k: 5, want: []WeightedKey{{"g", 9}, {"d", 5}, {"e", 5}, {"f", 5}, {"c", 2}}},
+		{k: 6, want: []WeightedKey{{"g", 9}, {"d", 5}, {"e", 5}, {"f", 5}, {"c", 2}, {"a", 1}}},
+	}
+	// Ties at the cutoff are broken by key whatever the shape of the tree.
+	for i := 0; i < 20; i++ {
+		tree := NewTernarySearchTree()
+		for _, j := range r.Perm(len(words)) {
+			tree.Insert(words[j].Key, words[j].Weight)
+		}
+		for _, test := range tests {
+			if diff := cmp.Diff(test.want, tree.TopK("", test.k)); diff != "" {
+				t.Fatalf("TopK(\"\", %d) produced unwanted keys (-want +got):\n%s", test.k, diff)
+			}
+		}
+	}
+}
+
+func TestTernarySearchTree_Autocomplete(t *testing.T) {
+	tree := NewTernarySearchTree()
+	words := map[string]int{
+		"car": 50, "card": 20, "care": 30, "career": 80, "cargo": 10, "cat": 70, "dog": 90, "über": 5,
+	}
+	for w, weight := range words {
+		tree.Insert(w, weight)
+	}
+	tests := []struct {
+		prefix string
+		k      int
+		want   []WeightedKey
+	}{
+		{prefix: "car", k: 3, want: []WeightedKey{{"career", 80}, {"car", 50}, {"care", 30}}},
+		{prefix: "ca", k: 2, want: []WeightedKey{{"career", 80}, {"cat", 70}}},
+		{prefix: "", k: 1, want: []WeightedKey{{"dog", 90}}},
+		{prefix: "ü", k: 5, want: []WeightedKey{{"über", 5}}},
+		{prefix: "x", k: 5, want: []WeightedKey{}},
+		{prefix: "car", k: 0, want: []WeightedKey{}},
+	}
+	for _, test := range tests {
+		got := tree.TopK(test.prefix, test.k)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("TopK(%q, %d) produced unwanted keys (-want +got):\n%s", test.prefix, test.k, diff)
+		}
+	}
+	want := []FuzzyMatch{
+		{"car", 50, 1}, {"care", 30, 1}, {"card", 20, 1}, {"career", 80, 2}, {"cat", 70, 2}, {"cargo", 10, 2},
+	}
+	if diff := cmp.Diff(want, tree.Fuzzy("carr", 2)); diff != "" {
+		t.Errorf(`Fuzzy("carr", 2) produced unwanted matches (-want +got):\n%s`, diff)
+	}
+	if got := tree.Fuzzy("uber", 1); len(got) != 1 || got[0].Key != "über" {
+		t.Errorf(`Fuzzy("uber", 1): %v, want über`, got)
+	}
+}
@@ -0,0 +1,101 @@
+package ads
+
+import "fmt"
+
+// validateDirected returns an error if the graph is undirected.
+func (g *Graph) validateDirected() error {
+	if !g.Directed() {
+		return fmt.Errorf("graph must be directed")
+	}
+	return nil
+}
+
+// TopologicalSort returns the vertices of a directed graph ordered so every edge goes from an
+// earlier vertex to a later one, using Kahn's algorithm: vertices with no incoming edges left are
+// taken in FIFO order, starting from the smallest one. If the graph isn't a DAG it returns a
+// *CycleError with one of its cycles.
+func (g *Graph) TopologicalSort() ([]int, error) {
+	if err := g.validateDirected(); err != nil {
+		return nil, err
+	}
+	n := len(g.adj)
+	indegree := make([]int, n)
+	for it := g.Edges(); it.Scan(); {
+		indegree[graphNextEdge(it).To]++
+	}
+	// Every vertex is enqueued at most once.
+	q := NewArrayBasedQueue(uint(n))
+	for v := range indegree {
+		if indegree[v] == 0 {
+			q.Push(v)
+		}
+	}
+	order := make([]int, 0, n)
+	for q.Size() > 0 {
+		u, _ := q.Pop()
+		order = append(order, u.(int))
+		for it := g.adj[u.(int)].Iterator(); it.Scan(); {
+			e := graphNextEdge(it)
+			if indegree[e.To]--; indegree[e.To] == 0 {
+				q.Push(e.To)
+			}
+		}
+	}
+	// The vertices left keep incoming edges from each other, so they contain a cycle.
+	if len(order) < n {
+		cycle, _ := g.FindCycle()
+		return nil, &CycleError{Cycle: cycle, kind: "cycle"}
+	}
+	return order, nil
+}
+
+// TopologicalSortDFS returns the vertices of a directed graph ordered so every edge goes from an
+// earlier vertex to a later one, as the reverse postorder of a depth-first search. If the graph
+// isn't a DAG it returns a *CycleError with one of its cycles.
+func (g *Graph) TopologicalSortDFS() ([]int, error) {
+	if err := g.validateDirected(); err != nil {
+		return nil, err
+	}
+	const (
+		white = iota // not visited yet
+		gray         // on the current DFS path
+		black        // finished
+	)
+	n := len(g.adj)
+	color := make([]int, n)
+	order := make([]int, n)
+	next := n - 1
+	s := NewArrayBasedStack(uint(n))
+	for src := range g.adj {
+		if color[src] != white {
+			continue
+		}
+		color[src] = gray
+		s.Push(&dfsFrame{v: src, edges: g.adj[src].Iterator()})
+		for s.Size() > 0 {
+			top, _ := s.Top()
+			f := top.(*dfsFrame)
+			if !f.edges.Scan() {
+				color[f.v] = black
+				order[next], next = f.v, next-1
+				s.Pop()
+				continue
+			}
+			switch e := graphNextEdge(f.edges); color[e.To] {
+			case white:
+				color[e.To] = gray
+				s.Push(&dfsFrame{v: e.To, edges: g.adj[e.To].Iterator()})
+			case gray:
+				// e closes a cycle with the DFS path from e.To, which is on the stack.
+				var cycle []int
+				for v := -1; v != e.To; {
+					top, _ := s.Pop()
+					v = top.(*dfsFrame).v
+					cycle = append(cycle, v)
+				}
+				return nil, &CycleError{Cycle: reversePath(cycle), kind: "cycle"}
+			}
+		}
+	}
+	return order, nil
+}
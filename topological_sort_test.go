@@ -0,0 +1,110 @@
+package ads
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// topologicalSorts lists every topological sort implementation.
+var topologicalSorts = []struct {
+	name string
+	run  func(g *Graph) ([]int, error)
+}{
+	{name: "kahn", run: (*Graph).TopologicalSort},
+	{name: "dfs", run: (*Graph).TopologicalSortDFS},
+}
+
+// checkTopologicalOrder verifies that order is a permutation of the vertices of g where every
+// edge goes forward.
+func checkTopologicalOrder(t *testing.T, g *Graph, order []int) {
+	t.Helper()
+	if len(order) != g.Order() {
+		t.Fatalf("order %v has %d vertices, want %d", order, len(order), g.Order())
+	}
+	position := make([]int, g.Order())
+	for i := range position {
+		position[i] = -1
+	}
+	for i, v := range order {
+		if position[v] != -1 {
+			t.Fatalf("order %v repeats vertex %d", order, v)
+		}
+		position[v] = i
+	}
+	for it := g.Edges(); it.Scan(); {
+		if e := graphNextEdge(it); position[e.From] >= position[e.To] {
+			t.Fatalf("edge %d -> %d goes backwards in order %v", e.From, e.To, order)
+		}
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	// CLRS, figure 22.7: undershorts=0, pants=1, belt=2, shirt=3, tie=4, jacket=5, socks=6,
+	// shoes=7, watch=8.
+	g := newTestGraph(t, 9, Directed, [][2]int{
+		{0, 1}, {0, 7}, {1, 2}, {1, 7}, {2, 5}, {3, 2}, {3, 4}, {4, 5}, {6, 7},
+	})
+	for _, s := range topologicalSorts {
+		t.Run(s.name, func(t *testing.T) {
+			order, err := s.run(g)
+			if err != nil {
+				t.Fatalf("returned unexpected error; %v", err)
+			}
+			checkTopologicalOrder(t, g, order)
+			if _, err := s.run(NewGraph(2, 0)); err == nil {
+				t.Error("returned nil error for an undirected graph, want error")
+			}
+			if order, err := s.run(NewGraph(0, Directed)); err != nil || len(order) != 0 {
+				t.Errorf("empty graph: %v, %v, want no vertices", order, err)
+			}
+		})
+	}
+	if order, _ := g.TopologicalSort(); !cmp.Equal(order, []int{0, 3, 6, 8, 1, 4, 2, 7, 5}) {
+		t.Errorf("TopologicalSort(): %v, want Kahn's FIFO order [0 3 6 8 1 4 2 7 5]", order)
+	}
+}
+
+func TestGraph_TopologicalSortDFSCycle(t *testing.T) {
+	// The DFS from 0 walks 0 -> 1 -> 2 -> 3 and finds the edge back to 1.
+	g := newTestGraph(t, 5, Directed, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 1}, {4, 4}})
+	_, err := g.TopologicalSortDFS()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("TopologicalSortDFS() returned %v, want a *CycleError", err)
+	}
+	if !cmp.Equal(cycleErr.Cycle, []int{1, 2, 3}) {
+		t.Errorf("TopologicalSortDFS() found cycle %v, want [1 2 3]", cycleErr.Cycle)
+	}
+	g = newTestGraph(t, 2, Directed, [][2]int{{0, 1}, {1, 1}})
+	_, err = g.TopologicalSortDFS()
+	if !errors.As(err, &cycleErr) || !cmp.Equal(cycleErr.Cycle, []int{1}) {
+		t.Errorf("TopologicalSortDFS() returned %v, want the self-loop cycle [1]", err)
+	}
+}
+
+func TestGraph_TopologicalSortCycles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(15)
+		g := randomGraph(r, n, r.Intn(2*n), 1, Directed)
+		_, hasCycle := g.FindCycle()
+		for _, s := range topologicalSorts {
+			order, err := s.run(g)
+			if !hasCycle {
+				if err != nil {
+					t.Fatalf("%s returned unexpected error for a DAG; %v", s.name, err)
+				}
+				checkTopologicalOrder(t, g, order)
+				continue
+			}
+			var cycleErr *CycleError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("%s returned %v, %v for a cyclic graph, want a *CycleError", s.name, order, err)
+			}
+			checkCycle(t, g, cycleErr.Cycle)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Treap is a randomized binary search tree: elements follow the search order while their random
+// priorities follow the max-heap order, which keeps the tree balanced with high probability. It
+// stores unique elements sorted by a Comparator and supports splitting and merging in O(log n).
+type Treap struct {
+	root *bstNode
+	cmp  Comparator
+	rng  *rand.Rand
+}
+
+// NewTreap returns an empty treap ordered by the given comparator. Priorities are drawn from a
+// random source initialized with seed.
+func NewTreap(cmp Comparator, seed int64) *Treap {
+	return &Treap{cmp: cmp, rng: rand.New(rand.NewSource(seed))}
+}
+
+func treapUpdate(n *bstNode) {
+	n.size = bstSize(n.left) + bstSize(n.right) + 1
+}
+
+// split the subtree rooted at n into the elements smaller than v (or equal to v, if inclusive)
+// and the rest.
+func (t *Treap) split(n *bstNode, v interface{}, inclusive bool) (*bstNode, *bstNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if c := t.cmp(n.value, v); c < 0 || (inclusive && c == 0) {
+		l, r := t.split(n.right, v, inclusive)
+		n.right = l
+		treapUpdate(n)
+		return n, r
+	}
+	l, r := t.split(n.left, v, inclusive)
+	n.left = r
+	treapUpdate(n)
+	return l, n
+}
+
+// merge two subtrees where every element of l is smaller than every element of r.
+func treapMerge(l, r *bstNode) *bstNode {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.rank > r.rank {
+		l.right = treapMerge(l.right, r)
+		treapUpdate(l)
+		return l
+	}
+	r.left = treapMerge(l, r.left)
+	treapUpdate(r)
+	return r
+}
+
+// Add inserts v into the treap. Elements already stored are ignored.
+func (t *Treap) Add(v interface{}) {
+	if t.Contains(v) {
+		return
+	}
+	l, r := t.split(t.root, v, false)
+	n := &bstNode{value: v, rank: t.rng.Int(), size: 1}
+	t.root = treapMerge(treapMerge(l, n), r)
+}
+
+// Remove deletes v from the treap (if exists).
+func (t *Treap) Remove(v interface{}) {
+	l, r := t.split(t.root, v, false)
+	_, r = t.split(r, v, true)
+	t.root = treapMerge(l, r)
+}
+
+// Contains returns whether an element is stored in the treap or not.
+func (t *Treap) Contains(v interface{}) bool {
+	return bstFind(t.root, t.cmp, v) != nil
+}
+
+// Size returns the number of elements stored in the treap.
+func (t *Treap) Size() int {
+	return bstSize(t.root)
+}
+
+// Empty removes all elements from the treap.
+func (t *Treap) Empty() {
+	t.root = nil
+}
+
+// Split moves the elements smaller than v into a new treap and the rest into another one, leaving
+// this treap empty.
+func (t *Treap) Split(v interface{}) (*Treap, *Treap) {
+	l, r := t.split(t.root, v, false)
+	t.root = nil
+	return &Treap{root: l, cmp: t.cmp, rng: t.rng}, &Treap{root: r, cmp: t.cmp, rng: t.rng}
+}
+
+// Merge moves all the elements of other into this treap, leaving other empty. Every element of
+// this treap must be smaller than every element of other.
+func (t *Treap) Merge(other *Treap) error {
+	if t == other || other.root == nil {
+		return nil
+	}
+	if t.root != nil {
+		max, _ := bstMax(t.root)
+		min, _ := bstMin(other.root)
+		if t.cmp(max, min) >= 0 {
+			return fmt.Errorf("cannot merge treaps, %v is not smaller than %v", max, min)
+		}
+	}
+	t.root = treapMerge(t.root, other.root)
+	other.root = nil
+	return nil
+}
+
+// Min returns the smallest element of the treap.
+func (t *Treap) Min() (interface{}, error) {
+	return bstMin(t.root)
+}
+
+// Max returns the largest element of the treap.
+func (t *Treap) Max() (interface{}, error) {
+	return bstMax(t.root)
+}
+
+// Floor returns the largest element smaller than or equal to v.
+func (t *Treap) Floor(v interface{}) (interface{}, error) {
+	return bstValue(bstUpperBound(t.root, t.cmp, v, true), "floor", v)
+}
+
+// Ceiling returns the smallest element greater than or equal to v.
+func (t *Treap) Ceiling(v interface{}) (interface{}, error) {
+	return bstValue(bstLowerBound(t.root, t.cmp, v, true), "ceiling", v)
+}
+
+// Predecessor returns the largest element strictly smaller than v.
+func (t *Treap) Predecessor(v interface{}) (interface{}, error) {
+	return bstValue(bstUpperBound(t.root, t.cmp, v, false), "predecessor", v)
+}
+
+// Successor returns the smallest element strictly greater than v.
+func (t *Treap) Successor(v interface{}) (interface{}, error) {
+	return bstValue(bstLowerBound(t.root, t.cmp, v, false), "successor", v)
+}
+
+// String returns a string representation of the treap content in sorted order.
+func (t *Treap) String() string {
+	return bstString(t.root)
+}
+
+// Iterator returns an iterable over the elements of the treap in sorted order.
+func (t *Treap) Iterator() Iterable {
+	return newBSTIterable(t.root, t.cmp, nil, nil)
+}
+
+// Range returns an iterable over the elements in the closed range [lo, hi] in sorted order.
+func (t *Treap) Range(lo, hi interface{}) Iterable {
+	return newBSTIterable(t.root, t.cmp, lo, hi)
+}
@@ -0,0 +1,99 @@
+package ads
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// checkTreapInvariants verifies the search order, the sizes and the heap order of priorities.
+func checkTreapInvariants(t *testing.T, tree *Treap) {
+	t.Helper()
+	checkBSTInvariants(t, tree.root, tree.cmp)
+	var walk func(n *bstNode)
+	walk = func(n *bstNode) {
+		for _, c := range []*bstNode{n.left, n.right} {
+			if c == nil {
+				continue
+			}
+			if c.rank > n.rank {
+				t.Fatalf("child %v has a higher priority than its parent %v", c.value, n.value)
+			}
+			walk(c)
+		}
+	}
+	if tree.root != nil {
+		walk(tree.root)
+	}
+}
+
+func TestTreap_ThroughOps(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		t.Run(fmt.Sprintf("n = %d", n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			tree := NewTreap(IntComparator, int64(n))
+			control := map[int]bool{}
+			for i := 0; i < 4*n; i++ {
+				v := r.Intn(2 * n)
+				if r.Intn(3) == 0 {
+					tree.Remove(v)
+					delete(control, v)
+				} else {
+					tree.Add(v)
+					control[v] = true
+				}
+				checkTreapInvariants(t, tree)
+				if got := tree.Contains(v); got != control[v] {
+					t.Fatalf("Contains(%d): %v, want %v", v, got, control[v])
+				}
+				if tree.Size() != len(control) {
+					t.Fatalf("Size(): %d, want %d", tree.Size(), len(control))
+				}
+			}
+		})
+	}
+}
+
+func TestTreap_SplitMerge(t *testing.T) {
+	tree := NewTreap(IntComparator, 1)
+	for i := 0; i < 100; i++ {
+		tree.Add(i * 2)
+	}
+	for _, pivot := range []int{-5, 0, 51, 100, 198, 300} {
+		left, right := tree.Split(pivot)
+		checkTreapInvariants(t, left)
+		checkTreapInvariants(t, right)
+		if tree.Size() != 0 {
+			t.Fatalf("Size(): %d after Split(%d), want 0", tree.Size(), pivot)
+		}
+		if max, err := left.Max(); err == nil && max.(int) >= pivot {
+			t.Fatalf("Split(%d) left part has %v", pivot, max)
+		}
+		if min, err := right.Min(); err == nil && min.(int) < pivot {
+			t.Fatalf("Split(%d) right part has %v", pivot, min)
+		}
+		if left.Size()+right.Size() != 100 {
+			t.Fatalf("Split(%d) sizes %d + %d, want 100", pivot, left.Size(), right.Size())
+		}
+		if err := right.Merge(left); left.Size() > 0 && right.Size() > 0 && err == nil {
+			t.Fatalf("Merge() of unordered treaps returned nil error, want error")
+		}
+		if err := left.Merge(right); err != nil {
+			t.Fatalf("Merge() returned unexpected error; %v", err)
+		}
+		checkTreapInvariants(t, left)
+		if right.Size() != 0 || left.Size() != 100 {
+			t.Fatalf("Merge() sizes %d and %d, want 100 and 0", left.Size(), right.Size())
+		}
+		tree = left
+	}
+	want := []interface{}{}
+	for i := 0; i < 100; i++ {
+		want = append(want, i*2)
+	}
+	if diff := cmp.Diff(want, iterableToSlice(t, tree.Iterator())); diff != "" {
+		t.Errorf("Iterator() produced unwanted elements (-want +got):\n%s", diff)
+	}
+}
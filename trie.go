@@ -0,0 +1,223 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// PrefixTree is an ordered map from strings to values supporting prefix queries. Keys are split
+// into runes, so a prefix always ends at a character boundary. Invalid UTF-8 bytes are read as
+// utf8.RuneError.
+type PrefixTree interface {
+	// Insert sets or updates the value stored at the given key.
+	Insert(k string, v interface{})
+	// Get the value stored in the given key. Returns nil, false if it doesn't exist.
+	Get(k string) (interface{}, bool)
+	// Delete the value stored at the given key (if exists).
+	Delete(k string)
+	// HasPrefix returns whether any stored key starts with p.
+	HasPrefix(p string) bool
+	// KeysWithPrefix returns an iterable over the stored keys starting with p in lexicographic
+	// order.
+	KeysWithPrefix(p string) Iterable
+	// LongestPrefixOf returns the longest stored key that is a prefix of s and its value.
+	// Returns "", nil, false if there's none.
+	LongestPrefixOf(s string) (string, interface{}, bool)
+	// Size returns the number of keys stored in the tree.
+	Size() int
+	// Empty removes all keys from the tree.
+	Empty()
+}
+
+// trieNode holds the rune of the edge coming from its parent, the value of the key ending at it
+// and its children sorted by rune.
+type trieNode struct {
+	r        rune
+	value    interface{}
+	terminal bool
+	children []*trieNode
+}
+
+// child returns the position where the child for r is (or would be) and whether it exists.
+func (n *trieNode) child(r rune) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].r >= r })
+	return i, i < len(n.children) && n.children[i].r == r
+}
+
+// Trie is a prefix tree with one node per rune.
+type Trie struct {
+	root   trieNode
+	length int
+}
+
+// NewTrie returns an empty trie.
+func NewTrie() *Trie {
+	return &Trie{}
+}
+
+// find returns the node reached by following the runes of k, or nil.
+func (t *Trie) find(k string) *trieNode {
+	n := &t.root
+	for _, r := range k {
+		i, ok := n.child(r)
+		if !ok {
+			return nil
+		}
+		n = n.children[i]
+	}
+	return n
+}
+
+// Insert sets or updates the value stored at the given key.
+func (t *Trie) Insert(k string, v interface{}) {
+	n := &t.root
+	for _, r := range k {
+		i, ok := n.child(r)
+		if !ok {
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = &trieNode{r: r}
+		}
+		n = n.children[i]
+	}
+	if !n.terminal {
+		n.terminal = true
+		t.length++
+	}
+	n.value = v
+}
+
+// Get the value stored in the given key. Returns nil, false if it doesn't exist.
+func (t *Trie) Get(k string) (interface{}, bool) {
+	if n := t.find(k); n != nil && n.terminal {
+		return n.value, true
+	}
+	return nil, false
+}
+
+// Delete the value stored at the given key (if exists). Nodes left without keys are pruned.
+func (t *Trie) Delete(k string) {
+	path := []*trieNode{&t.root}
+	for _, r := range k {
+		n := path[len(path)-1]
+		i, ok := n.child(r)
+		if !ok {
+			return
+		}
+		path = append(path, n.children[i])
+	}
+	n := path[len(path)-1]
+	if !n.terminal {
+		return
+	}
+	n.terminal, n.value = false, nil
+	t.length--
+	for j := len(path) - 1; j > 0 && !path[j].terminal && len(path[j].children) == 0; j-- {
+		parent := path[j-1]
+		i, _ := parent.child(path[j].r)
+		copy(parent.children[i:], parent.children[i+1:])
+		// Avoid memory leaks (free references for garbage collector)
+		parent.children[len(parent.children)-1] = nil
+		parent.children = parent.children[:len(parent.children)-1]
+	}
+}
+
+// HasPrefix returns whether any stored key starts with p.
+func (t *Trie) HasPrefix(p string) bool {
+	// Every node but the root is on the path of some key.
+	n := t.find(p)
+	return n != nil && (n.terminal || len(n.children) > 0)
+}
+
+// KeysWithPrefix returns an iterable over the stored keys starting with p in lexicographic order.
+func (t *Trie) KeysWithPrefix(p string) Iterable {
+	it := &TrieIterable{}
+	if n := t.find(p); n != nil {
+		it.stack = []trieFrame{{n: n, key: []rune(p)}}
+		it.advance()
+	}
+	return it
+}
+
+// LongestPrefixOf returns the longest stored key that is a prefix of s and its value. Returns
+// "", nil, false if there's none.
+func (t *Trie) LongestPrefixOf(s string) (string, interface{}, bool) {
+	n, end, found := &t.root, 0, t.root.terminal
+	value := t.root.value
+	for j, r := range s {
+		i, ok := n.child(r)
+		if !ok {
+			break
+		}
+		n = n.children[i]
+		if n.terminal {
+			_, size := utf8.DecodeRuneInString(s[j:])
+			end, value, found = j+size, n.value, true
+		}
+	}
+	if !found {
+		return "", nil, false
+	}
+	return s[:end], value, true
+}
+
+// Size returns the number of keys stored in the trie.
+func (t *Trie) Size() int {
+	return t.length
+}
+
+// Empty removes all keys from the trie.
+func (t *Trie) Empty() {
+	t.root = trieNode{}
+	t.length = 0
+}
+
+// trieFrame is a node pending to be visited and the key spelled by the path to it.
+type trieFrame struct {
+	n   *trieNode
+	key []rune
+}
+
+// TrieIterable implements Iterable interface for Trie, returning string keys. It walks the trie
+// in preorder using an explicit stack.
+type TrieIterable struct {
+	stack []trieFrame
+	// next is the key returned by the next call to Next, valid if hasNext.
+	next    string
+	hasNext bool
+}
+
+// advance walks the trie until the next key is found.
+func (i *TrieIterable) advance() {
+	i.hasNext = false
+	for len(i.stack) > 0 && !i.hasNext {
+		f := i.stack[len(i.stack)-1]
+		i.stack = i.stack[:len(i.stack)-1]
+		for j := len(f.n.children) - 1; j >= 0; j-- {
+			c := f.n.children[j]
+			key := make([]rune, len(f.key)+1)
+			copy(key, f.key)
+			key[len(f.key)] = c.r
+			i.stack = append(i.stack, trieFrame{n: c, key: key})
+		}
+		if f.n.terminal {
+			i.next, i.hasNext = string(f.key), true
+		}
+	}
+}
+
+// Scan returns a boolean indicating if there's a next element or not.
+func (i *TrieIterable) Scan() bool {
+	return i.hasNext
+}
+
+// Next returns the next element in the iterable.
+func (i *TrieIterable) Next() (interface{}, error) {
+	if !i.Scan() {
+		return nil, fmt.Errorf("there isn't a next element")
+	}
+	k := i.next
+	i.advance()
+	return k, nil
+}
@@ -0,0 +1,193 @@
+package ads
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func logPrefixTreeSatisfaction(t *testing.T, ds string, p PrefixTree) {
+	t.Helper()
+	t.Logf("%s satisfies PrefixTree interface: %v", ds, p)
+}
+
+// TestPrefixTreeInterfaceSatisfaction verifies (during compilation) that the
+// multiple prefix tree implementations satisfy the PrefixTree interface.
+func TestPrefixTreeInterfaceSatisfaction(t *testing.T) {
+	var p PrefixTree
+
+	p = NewTrie()
+	logPrefixTreeSatisfaction(t, "Trie", p)
+	p = NewRadixTree()
+	logPrefixTreeSatisfaction(t, "RadixTree", p)
+}
+
+// prefixTreeFactories lists every PrefixTree implementation checked by the conformance suite.
+var prefixTreeFactories = []struct {
+	name string
+	new  func() PrefixTree
+}{
+	{name: "trie", new: func() PrefixTree { return NewTrie() }},
+	{name: "radix tree", new: func() PrefixTree { return NewRadixTree() }},
+}
+
+// prefixTreeAlphabet mixes one, two, three and four byte runes so prefixes are checked at
+// character boundaries.
+var prefixTreeAlphabet = []rune{'a', 'b', 'é', 'ñ', '日', '本', '🙂'}
+
+// randomRuneString returns a string of up to maxLen runes drawn from prefixTreeAlphabet.
+func randomRuneString(r *rand.Rand, maxLen int) string {
+	rs := make([]rune, r.Intn(maxLen+1))
+	for i := range rs {
+		rs[i] = prefixTreeAlphabet[r.Intn(len(prefixTreeAlphabet))]
+	}
+	return string(rs)
+}
+
+func TestPrefixTree_Conformance(t *testing.T) {
+	for _, f := range prefixTreeFactories {
+		t.Run(f.name, func(t *testing.T) {
+			t.Run("empty tree", func(t *testing.T) {
+				p := f.new()
+				if _, ok := p.Get(""); ok {
+					t.Error(`Get("") found a key in an empty tree`)
+				}
+				if p.HasPrefix("") {
+					t.Error(`HasPrefix("") returned true for an empty tree`)
+				}
+				if p.KeysWithPrefix("").Scan() {
+					t.Error(`KeysWithPrefix("") returned keys for an empty tree`)
+				}
+				if k, v, ok := p.LongestPrefixOf("abc"); ok {
+					t.Errorf(`LongestPrefixOf("abc"): %q, %v, true for an empty tree`, k, v)
+				}
+				p.Delete("abc")
+				if p.Size() != 0 {
+					t.Errorf("Size(): %d, want 0", p.Size())
+				}
+			})
+
+			t.Run("through ops", func(t *testing.T) {
+				r := rand.New(rand.NewSource(1))
+				p := f.new()
+				control := map[string]int{}
+				for i := 0; i < 3000; i++ {
+					k := randomRuneString(r, 5)
+					if r.Intn(3) == 0 {
+						p.Delete(k)
+						delete(control, k)
+					} else {
+						p.Insert(k, i)
+						control[k] = i
+					}
+					if p.Size() != len(control) {
+						t.Fatalf("Size(): %d, want %d", p.Size(), len(control))
+					}
+					q := randomRuneString(r, 5)
+					got, ok := p.Get(q)
+					want, wantOk := control[q]
+					if ok != wantOk || (ok && got != want) {
+						t.Fatalf("Get(%q): %v, %v, want %v, %v", q, got, ok, want, wantOk)
+					}
+				}
+				for i := 0; i < 500; i++ {
+					q := randomRuneString(r, 3)
+					want := []interface{}{}
+					var longest string
+					found := false
+					for k := range control {
+						if strings.HasPrefix(k, q) {
+							want = append(want, k)
+						}
+						if strings.HasPrefix(q, k) && (!found || len(k) > len(longest)) {
+							longest, found = k, true
+						}
+					}
+					sort.Slice(want, func(i, j int) bool { return want[i].(string) < want[j].(string) })
+					if diff := cmp.Diff(want, iterableToSlice(t, p.KeysWithPrefix(q))); diff != "" {
+						t.Fatalf("KeysWithPrefix(%q) produced unwanted keys (-want +got):\n%s", q, diff)
+					}
+					if got := p.HasPrefix(q); got != (len(want) > 0) {
+						t.Fatalf("HasPrefix(%q): %v, want %v", q, got, len(want) > 0)
+					}
+					k, v, ok := p.LongestPrefixOf(q)
+					if ok != found || k != longest || (ok && v != control[k]) {
+						t.Fatalf("LongestPrefixOf(%q): %q, %v, %v, want %q, %v, %v",
+							q, k, v, ok, longest, control[longest], found)
+					}
+				}
+				p.Empty()
+				if p.Size() != 0 || p.HasPrefix("") {
+					t.Error("Empty() left keys in the tree")
+				}
+			})
+
+			t.Run("routing table", func(t *testing.T) {
+				p := f.new()
+				for _, route := range []string{"/", "/api", "/api/v1", "/api/v1/users", "/static"} {
+					p.Insert(route, route+" handler")
+				}
+				tests := []struct {
+					path, want string
+				}{
+					{path: "/api/v1/users/42", want: "/api/v1/users"},
+					{path: "/api/v2", want: "/api"},
+					{path: "/apiary", want: "/api"},
+					{path: "/static/日本.png", want: "/static"},
+					{path: "/", want: "/"},
+					{path: "/other", want: "/"},
+				}
+				for _, test := range tests {
+					k, v, ok := p.LongestPrefixOf(test.path)
+					if !ok || k != test.want || v != test.want+" handler" {
+						t.Errorf("LongestPrefixOf(%q): %q, %v, %v, want %q", test.path, k, v, ok, test.want)
+					}
+				}
+				if k, _, ok := p.LongestPrefixOf("api"); ok {
+					t.Errorf(`LongestPrefixOf("api"): %q, want no match`, k)
+				}
+			})
+
+			t.Run("prefix in the middle of a rune", func(t *testing.T) {
+				p := f.new()
+				p.Insert("日本", 1)
+				// "日" and "本" share their first byte, so a byte-based tree would match it.
+				if p.HasPrefix("\xe6") {
+					t.Error("HasPrefix() matched a partial rune")
+				}
+				if k, _, ok := p.LongestPrefixOf("日本語"); !ok || k != "日本" {
+					t.Errorf(`LongestPrefixOf("日本語"): %q, %v, want "日本", true`, k, ok)
+				}
+			})
+		})
+	}
+}
+
+// trieNodes returns the number of nodes of the trie, root included.
+func trieNodes(n *trieNode) int {
+	count := 1
+	for _, c := range n.children {
+		count += trieNodes(c)
+	}
+	return count
+}
+
+func TestTrie_DeletePrunes(t *testing.T) {
+	trie := NewTrie()
+	trie.Insert("añil", 1)
+	trie.Insert("año", 2)
+	if got := trieNodes(&trie.root); got != 6 {
+		t.Fatalf("trie has %d nodes, want 6", got)
+	}
+	trie.Delete("añil")
+	if got := trieNodes(&trie.root); got != 4 {
+		t.Errorf("trie has %d nodes after Delete(), want 4", got)
+	}
+	trie.Delete("año")
+	if got := trieNodes(&trie.root); got != 1 {
+		t.Errorf("trie has %d nodes after deleting every key, want 1", got)
+	}
+}
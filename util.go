@@ -0,0 +1,26 @@
+package ads
+
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// minInts returns the smallest of the given integers.
+func minInts(v int, vs ...int) int {
+	for _, w := range vs {
+		if w < v {
+			v = w
+		}
+	}
+	return v
+}
+
+// maxInts returns the largest of the given integers.
+func maxInts(v int, vs ...int) int {
+	for _, w := range vs {
+		if w > v {
+			v = w
+		}
+	}
+	return v
+}